@@ -0,0 +1,81 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/kbence/logan/config"
+)
+
+var queryReferenceMatcher = regexp.MustCompile("^@([A-Za-z0-9_.-]+)$")
+
+// resolveQuery replaces references to saved queries (@name) with the filter
+// expression stored in the configuration
+func resolveQuery(cfg *config.Configuration, filter string) (string, error) {
+	match := queryReferenceMatcher.FindStringSubmatch(strings.TrimSpace(filter))
+
+	if match == nil {
+		return filter, nil
+	}
+
+	query, found := cfg.Queries[match[1]]
+
+	if !found {
+		return "", fmt.Errorf("saved query '%s' not found in the [queries] section", match[1])
+	}
+
+	return query, nil
+}
+
+// readFilterFile reads a filter expression from a file. Lines starting with
+// '#' are comments, they are blanked so that line numbers in syntax errors
+// still match the file.
+func readFilterFile(fileName string) (string, error) {
+	content, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(content), "\r\n"), "\n")
+
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			lines[i] = ""
+		}
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// collectFilters returns the filter expressions passed in the arguments and
+// in the filter file (if any), with saved queries resolved
+func collectFilters(cfg *config.Configuration, args []string, filterFile string) ([]string, error) {
+	filters := append([]string{}, args...)
+
+	if filterFile != "" {
+		filter, err := readFilterFile(filterFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.TrimSpace(filter) != "" {
+			filters = append(filters, filter)
+		}
+	}
+
+	for i, filter := range filters {
+		query, err := resolveQuery(cfg, filter)
+
+		if err != nil {
+			return nil, err
+		}
+
+		filters[i] = query
+	}
+
+	return filters, nil
+}
@@ -0,0 +1,22 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kbence/logan/config"
+	"github.com/kbence/logan/parser"
+)
+
+// registerFormats makes the formats defined in the configuration available
+func registerFormats(cfg *config.Configuration) {
+	for name, format := range cfg.Formats {
+		parser.RegisterRegexFormat(name, format.Pattern, format.TimeLayout)
+	}
+}
+
+// formatUsage returns the description of the --format flag
+func formatUsage() string {
+	return fmt.Sprintf("Log format, one of the following: %s. Overrides the format set for the category.",
+		strings.Join(parser.FormatNames(), ", "))
+}
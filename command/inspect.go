@@ -15,6 +15,10 @@ import (
 // the current time and filter specification
 func NewInspectCommand(cfg *config.Configuration) *cobra.Command {
 	var timeInterval string
+	var filterFile string
+	var format string
+	var multiline bool
+	var dateFormats []string
 
 	inspectCommand := &cobra.Command{
 		Use:   "inspect",
@@ -24,18 +28,36 @@ func NewInspectCommand(cfg *config.Configuration) *cobra.Command {
 				log.Fatal("You have to pass a log source to this command!")
 			}
 
+			filters, err := collectFilters(cfg, args[1:], filterFile)
+			if err != nil {
+				log.Fatalf("ERROR: %s", err)
+			}
+
+			location, err := getLocation(cmd, cfg, args[0])
+			if err != nil {
+				log.Fatalf("ERROR: %s", err)
+			}
+
 			p := pipeline.NewPipelineBuilder(pipeline.PipelineSettings{
-				Category: args[0],
-				Interval: utils.ParseTimeInterval(timeInterval, time.Now()),
-				Filters:  args[1:],
-				Fields:   utils.ParseIntervals(""),
-				Config:   cfg,
-				Output:   pipeline.OutputTypeInspector})
+				Category:    args[0],
+				Format:      format,
+				Multiline:   multiline,
+				DateFormats: dateFormats,
+				Location:    location,
+				Interval:    utils.ParseTimeInterval(timeInterval, time.Now().In(location)),
+				Filters:     filters,
+				Fields:      utils.ParseIntervals(""),
+				Config:      cfg,
+				Output:      pipeline.OutputTypeInspector})
 			p.Execute()
 		},
 	}
 
 	inspectCommand.Flags().StringVarP(&timeInterval, "time", "t", "-1h", "Example: -1h5m+5m")
+	inspectCommand.Flags().StringVarP(&filterFile, "filter-file", "", "", "Reads an additional filter expression from a file")
+	inspectCommand.Flags().StringVarP(&format, "format", "", "", formatUsage())
+	inspectCommand.Flags().BoolVarP(&multiline, "multiline", "", false, "Groups lines without a date (e.g. stack traces) with the preceding line")
+	inspectCommand.Flags().StringArrayVarP(&dateFormats, "date-format", "", nil, "Adds a date format: a Go time layout, optionally followed by |FIELD or |REGEX")
 
 	return inspectCommand
 }
@@ -14,8 +14,11 @@ import (
 // logan commands and starts/stops go trace
 func NewLoganCommand(cfg *config.Configuration) *cobra.Command {
 	var traceFilename string
+	var timeZone string
 	var traceFile *os.File
 
+	registerFormats(cfg)
+
 	command := &cobra.Command{
 		Use:   "logan",
 		Short: "Command line tool for analyzing logs",
@@ -44,6 +47,8 @@ func NewLoganCommand(cfg *config.Configuration) *cobra.Command {
 	}
 
 	command.PersistentFlags().StringVarP(&traceFilename, "trace", "", "", "Saves go trace to the specified file")
+	command.PersistentFlags().StringVarP(&timeZone, "tz", "", "",
+		"Time zone of the time interval and of dates without one, e.g. UTC or Europe/Budapest (default: local)")
 	command.AddCommand(NewListCommand(cfg))
 	command.AddCommand(NewInspectCommand(cfg))
 	command.AddCommand(NewShowCommand(cfg))
@@ -17,6 +17,10 @@ import (
 // plotting a chart
 func NewPlotCommand(cfg *config.Configuration) *cobra.Command {
 	var timeInterval string
+	var filterFile string
+	var format string
+	var multiline bool
+	var dateFormats []string
 	var fields string
 	var mode string
 	var autoUpdate bool
@@ -29,16 +33,30 @@ func NewPlotCommand(cfg *config.Configuration) *cobra.Command {
 				log.Fatal("You have to pass a log source to this command!")
 			}
 
-			interval := utils.ParseTimeInterval(timeInterval, time.Now())
+			filters, err := collectFilters(cfg, args[1:], filterFile)
+			if err != nil {
+				log.Fatalf("ERROR: %s", err)
+			}
+
+			location, err := getLocation(cmd, cfg, args[0])
+			if err != nil {
+				log.Fatalf("ERROR: %s", err)
+			}
+
+			interval := utils.ParseTimeInterval(timeInterval, time.Now().In(location))
 			width, height := utils.GetTerminalDimensions()
 
 			p := pipeline.NewPipelineBuilder(pipeline.PipelineSettings{
-				Category: args[0],
-				Interval: interval,
-				Filters:  args[1:],
-				Fields:   utils.ParseIntervals(fields),
-				Config:   cfg,
-				Output:   pipeline.OutputTypeLineChart,
+				Category:    args[0],
+				Format:      format,
+				Multiline:   multiline,
+				DateFormats: dateFormats,
+				Location:    location,
+				Interval:    interval,
+				Filters:     filters,
+				Fields:      utils.ParseIntervals(fields),
+				Config:      cfg,
+				Output:      pipeline.OutputTypeLineChart,
 				OutputSettings: pipeline.LineChartSettings{
 					Mode:            mode,
 					Width:           width,
@@ -50,7 +68,11 @@ func NewPlotCommand(cfg *config.Configuration) *cobra.Command {
 	}
 
 	plotCommand.Flags().StringVarP(&timeInterval, "time", "t", "-1h", "Example: -1h5m+5m")
-	plotCommand.Flags().StringVarP(&fields, "fields", "f", "", "Example: 1,2,3")
+	plotCommand.Flags().StringVarP(&filterFile, "filter-file", "", "", "Reads an additional filter expression from a file")
+	plotCommand.Flags().StringVarP(&format, "format", "", "", formatUsage())
+	plotCommand.Flags().BoolVarP(&multiline, "multiline", "", false, "Groups lines without a date (e.g. stack traces) with the preceding line")
+	plotCommand.Flags().StringArrayVarP(&dateFormats, "date-format", "", nil, "Adds a date format: a Go time layout, optionally followed by |FIELD or |REGEX")
+	plotCommand.Flags().StringVarP(&fields, "fields", "f", "", "Example: 1,2,3 or level,request.status")
 	plotCommand.Flags().StringVarP(&mode, "mode", "m", "braille",
 		fmt.Sprintf("One of the following modes: %s.", strings.Join(types.CharacterSets.GetNames(), ", ")))
 	plotCommand.Flags().BoolVarP(&autoUpdate, "auto-update", "u", true, "Auto-update chart during log parsing")
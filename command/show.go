@@ -14,6 +14,10 @@ import (
 // (almost) raw lines of output
 func NewShowCommand(cfg *config.Configuration) *cobra.Command {
 	var timeInterval string
+	var filterFile string
+	var format string
+	var multiline bool
+	var dateFormats []string
 	var fields string
 
 	showCommand := &cobra.Command{
@@ -24,19 +28,37 @@ func NewShowCommand(cfg *config.Configuration) *cobra.Command {
 				log.Fatal("You have to pass a log source to this command!")
 			}
 
+			filters, err := collectFilters(cfg, args[1:], filterFile)
+			if err != nil {
+				log.Fatalf("ERROR: %s", err)
+			}
+
+			location, err := getLocation(cmd, cfg, args[0])
+			if err != nil {
+				log.Fatalf("ERROR: %s", err)
+			}
+
 			p := pipeline.NewPipelineBuilder(pipeline.PipelineSettings{
-				Category: args[0],
-				Interval: utils.ParseTimeInterval(timeInterval, time.Now()),
-				Filters:  args[1:],
-				Fields:   utils.ParseIntervals(fields),
-				Config:   cfg,
-				Output:   pipeline.OutputTypeLogLines})
+				Category:    args[0],
+				Format:      format,
+				Multiline:   multiline,
+				DateFormats: dateFormats,
+				Location:    location,
+				Interval:    utils.ParseTimeInterval(timeInterval, time.Now().In(location)),
+				Filters:     filters,
+				Fields:      utils.ParseIntervals(fields),
+				Config:      cfg,
+				Output:      pipeline.OutputTypeLogLines})
 			p.Execute()
 		},
 	}
 
 	showCommand.Flags().StringVarP(&timeInterval, "time", "t", "-1h", "Example: -1h5m+5m")
-	showCommand.Flags().StringVarP(&fields, "fields", "f", "", "Example: 1,2,3")
+	showCommand.Flags().StringVarP(&filterFile, "filter-file", "", "", "Reads an additional filter expression from a file")
+	showCommand.Flags().StringVarP(&format, "format", "", "", formatUsage())
+	showCommand.Flags().BoolVarP(&multiline, "multiline", "", false, "Groups lines without a date (e.g. stack traces) with the preceding line")
+	showCommand.Flags().StringArrayVarP(&dateFormats, "date-format", "", nil, "Adds a date format: a Go time layout, optionally followed by |FIELD or |REGEX")
+	showCommand.Flags().StringVarP(&fields, "fields", "f", "", "Example: 1,2,3 or level,request.status")
 
 	return showCommand
 }
@@ -0,0 +1,32 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kbence/logan/config"
+	"github.com/kbence/logan/pipeline"
+	"github.com/spf13/cobra"
+)
+
+// getLocation returns the time zone of the category: the one given with
+// --tz, the one set for the category, or the local one
+func getLocation(cmd *cobra.Command, cfg *config.Configuration, category string) (*time.Location, error) {
+	name, _ := cmd.Flags().GetString("tz")
+
+	if name == "" {
+		name = pipeline.GetCategorySettings(cfg, category).TimeZone
+	}
+
+	if name == "" {
+		return time.Local, nil
+	}
+
+	location, err := time.LoadLocation(name)
+
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone '%s'", name)
+	}
+
+	return location, nil
+}
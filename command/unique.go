@@ -14,6 +14,10 @@ import (
 // uniq output
 func NewUniqCommand(cfg *config.Configuration) *cobra.Command {
 	var timeInterval string
+	var filterFile string
+	var format string
+	var multiline bool
+	var dateFormats []string
 	var fields string
 	var topLimit int
 
@@ -25,18 +29,32 @@ func NewUniqCommand(cfg *config.Configuration) *cobra.Command {
 				log.Fatal("You have to pass a log source to this command!")
 			}
 
+			filters, err := collectFilters(cfg, args[1:], filterFile)
+			if err != nil {
+				log.Fatalf("ERROR: %s", err)
+			}
+
+			location, err := getLocation(cmd, cfg, args[0])
+			if err != nil {
+				log.Fatalf("ERROR: %s", err)
+			}
+
 			width, height := utils.GetTerminalDimensions()
 			if topLimit > height-1 {
 				topLimit = height - 1
 			}
 
 			p := pipeline.NewPipelineBuilder(pipeline.PipelineSettings{
-				Category: args[0],
-				Interval: utils.ParseTimeInterval(timeInterval, time.Now()),
-				Filters:  args[1:],
-				Fields:   utils.ParseIntervals(fields),
-				Config:   cfg,
-				Output:   pipeline.OutputTypeUniqueLines,
+				Category:    args[0],
+				Format:      format,
+				Multiline:   multiline,
+				DateFormats: dateFormats,
+				Location:    location,
+				Interval:    utils.ParseTimeInterval(timeInterval, time.Now().In(location)),
+				Filters:     filters,
+				Fields:      utils.ParseIntervals(fields),
+				Config:      cfg,
+				Output:      pipeline.OutputTypeUniqueLines,
 				OutputSettings: pipeline.UniqueSettings{
 					TopLimit:      topLimit,
 					TerminalWidth: width,
@@ -46,7 +64,11 @@ func NewUniqCommand(cfg *config.Configuration) *cobra.Command {
 	}
 
 	uniqCommand.Flags().StringVarP(&timeInterval, "time", "t", "-1h", "Example: -1h5m+5m")
-	uniqCommand.Flags().StringVarP(&fields, "fields", "f", "", "Example: 1,2,3")
+	uniqCommand.Flags().StringVarP(&filterFile, "filter-file", "", "", "Reads an additional filter expression from a file")
+	uniqCommand.Flags().StringVarP(&format, "format", "", "", formatUsage())
+	uniqCommand.Flags().BoolVarP(&multiline, "multiline", "", false, "Groups lines without a date (e.g. stack traces) with the preceding line")
+	uniqCommand.Flags().StringArrayVarP(&dateFormats, "date-format", "", nil, "Adds a date format: a Go time layout, optionally followed by |FIELD or |REGEX")
+	uniqCommand.Flags().StringVarP(&fields, "fields", "f", "", "Example: 1,2,3 or level,request.status")
 	uniqCommand.Flags().IntVarP(&topLimit, "top", "T", 0, "Show only the top N results")
 
 	return uniqCommand
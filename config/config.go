@@ -4,11 +4,32 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/go-ini/ini"
 )
 
+// CategorySettings describes how the lines of a log category are parsed
+type CategorySettings struct {
+	Format      string
+	Fields      []string
+	TimeKey     string
+	Multiline   bool
+	RecordStart string
+	DateFormat  string
+	EpochUnit   string
+	DateColumn  int
+	DateSearch  bool
+	TimeZone    string
+}
+
+// FormatSettings describes a user-defined log format
+type FormatSettings struct {
+	Pattern    string
+	TimeLayout string
+}
+
 // Configuration describes Logan's settings
 type Configuration struct {
 	Scribe struct {
@@ -18,13 +39,32 @@ type Configuration struct {
 		Dirs     []string
 		MaxDepth int
 	}
+	JSON struct {
+		TimeKey string
+	}
+	Logfmt struct {
+		TimeKey string
+	}
+	Queries     map[string]string
+	Categories  map[string]*CategorySettings
+	Formats     map[string]*FormatSettings
+	DateFormats []string
 }
 
 const (
-	scribeSection  = "scribe"
-	genericSection = "generic"
+	scribeSection      = "scribe"
+	genericSection     = "generic"
+	queriesSection     = "queries"
+	jsonSection        = "json"
+	logfmtSection      = "logfmt"
+	dateFormatsSection = "date_formats"
+
+	formatKeyPrefix     = "format."
+	timeLayoutKeySuffix = ".time_layout"
 )
 
+var categorySectionMatcher = regexp.MustCompile(`^category\s+"(.+)"$`)
+
 type iniFile ini.File
 
 func (f *iniFile) extractDirs(section, defaultValue string) []string {
@@ -38,11 +78,99 @@ func (f *iniFile) extractDirs(section, defaultValue string) []string {
 	return dirs
 }
 
+// extractCategories collects the settings of the [category "name"] sections
+func (f *iniFile) extractCategories() map[string]*CategorySettings {
+	categories := map[string]*CategorySettings{}
+
+	for _, section := range (*ini.File)(f).Sections() {
+		match := categorySectionMatcher.FindStringSubmatch(section.Name())
+
+		if match == nil {
+			continue
+		}
+
+		settings := &CategorySettings{
+			Format:      section.Key("format").String(),
+			TimeKey:     section.Key("time_key").String(),
+			Multiline:   section.Key("multiline").MustBool(false),
+			RecordStart: section.Key("record_start").String(),
+			DateFormat:  section.Key("date_format").String(),
+			EpochUnit:   section.Key("epoch_unit").String(),
+			DateColumn:  section.Key("date_column").MustInt(0),
+			DateSearch:  section.Key("date_search").MustBool(false),
+			TimeZone:    section.Key("timezone").String(),
+		}
+
+		if fields := section.Key("fields").String(); fields != "" {
+			for _, field := range strings.Split(fields, ",") {
+				settings.Fields = append(settings.Fields, strings.TrimSpace(field))
+			}
+		}
+
+		categories[match[1]] = settings
+	}
+
+	return categories
+}
+
+// extractFormats collects the user-defined formats from the format.NAME and
+// format.NAME.time_layout keys
+func (f *iniFile) extractFormats() map[string]*FormatSettings {
+	formats := map[string]*FormatSettings{}
+
+	get := func(name string) *FormatSettings {
+		if formats[name] == nil {
+			formats[name] = &FormatSettings{}
+		}
+
+		return formats[name]
+	}
+
+	for _, key := range (*ini.File)(f).Section(ini.DefaultSection).Keys() {
+		name := strings.TrimPrefix(key.Name(), formatKeyPrefix)
+
+		if name == key.Name() {
+			continue
+		}
+
+		if strings.HasSuffix(name, timeLayoutKeySuffix) {
+			get(strings.TrimSuffix(name, timeLayoutKeySuffix)).TimeLayout = key.String()
+		} else {
+			get(name).Pattern = key.String()
+		}
+	}
+
+	return formats
+}
+
+// extractDateFormats returns the values of the [date_formats] section in
+// the order they are listed, the keys are only there to name them
+func (f *iniFile) extractDateFormats() []string {
+	formats := []string{}
+
+	for _, key := range (*ini.File)(f).Section(dateFormatsSection).Keys() {
+		formats = append(formats, key.String())
+	}
+
+	return formats
+}
+
+// loadOptions are used for most of the settings, missing files are skipped
+var loadOptions = ini.LoadOptions{Loose: true}
+
+// rawLoadOptions keep values as they are written, they are used for the
+// queries, formats and date formats: filters, patterns and date formats may
+// contain ';', '#' and quotes, which go-ini would treat as the start of a
+// comment or strip by default
+var rawLoadOptions = ini.LoadOptions{
+	Loose:                   true,
+	IgnoreInlineComment:     true,
+	PreserveSurroundedQuote: true,
+}
+
 // Load tries to load configuration from several locations
 func Load() *Configuration {
-	var config Configuration
-
-	cfg, err := ini.LooseLoad(
+	config, err := loadSources(
 		"/etc/logan.conf",
 		fmt.Sprintf("%s/.logan.conf", os.Getenv("HOME")))
 
@@ -50,9 +178,35 @@ func Load() *Configuration {
 		log.Panicf("ERROR: %s\n", err)
 	}
 
+	return config
+}
+
+// loadSources loads the configuration from files (missing ones are skipped)
+// or raw data, later sources override the earlier ones
+func loadSources(source interface{}, others ...interface{}) (*Configuration, error) {
+	var config Configuration
+
+	cfg, err := ini.LoadSources(loadOptions, source, others...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := ini.LoadSources(rawLoadOptions, source, others...)
+
+	if err != nil {
+		return nil, err
+	}
+
 	config.Scribe.Dirs = (*iniFile)(cfg).extractDirs(scribeSection, "/mnt/scribe:/var/log/scribe")
 	config.Generic.Dirs = (*iniFile)(cfg).extractDirs(genericSection, "/var/log")
 	config.Generic.MaxDepth = cfg.Section(genericSection).Key("recursion").MustInt(1)
+	config.JSON.TimeKey = cfg.Section(jsonSection).Key("time_key").String()
+	config.Logfmt.TimeKey = cfg.Section(logfmtSection).Key("time_key").String()
+	config.Queries = raw.Section(queriesSection).KeysHash()
+	config.Categories = (*iniFile)(cfg).extractCategories()
+	config.Formats = (*iniFile)(raw).extractFormats()
+	config.DateFormats = (*iniFile)(raw).extractDateFormats()
 
-	return &config
+	return &config, nil
 }
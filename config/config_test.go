@@ -0,0 +1,69 @@
+package config
+
+import (
+	"testing"
+)
+
+const testConfig = `
+format.pair = ^(?P<time>\S+) (?P<pair>\d+;\d+)$
+
+[queries]
+semi = $1 ~= "a;b"
+hash = $1 == "#1"
+free = "timeout"
+
+[date_formats]
+pair = 2006-01-02|(\d+;\d+)
+
+[generic]
+dirs = "/var/log"
+recursion = 2 ; depth
+`
+
+func TestLoadKeepsCommentCharactersAndQuotes(t *testing.T) {
+	config, err := loadSources([]byte(testConfig))
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for name, expected := range map[string]string{
+		"semi": `$1 ~= "a;b"`,
+		"hash": `$1 == "#1"`,
+		"free": `"timeout"`,
+	} {
+		if query := config.Queries[name]; query != expected {
+			t.Errorf("Query '%s' was loaded as '%s' instead of '%s'!", name, query, expected)
+		}
+	}
+
+	if pattern := config.Formats["pair"].Pattern; pattern != `^(?P<time>\S+) (?P<pair>\d+;\d+)$` {
+		t.Errorf("Format pattern was loaded as '%s'!", pattern)
+	}
+
+	if len(config.DateFormats) != 1 || config.DateFormats[0] != `2006-01-02|(\d+;\d+)` {
+		t.Errorf("Date formats were loaded as %v!", config.DateFormats)
+	}
+}
+
+func TestLoadParsesOtherSettingsAsUsual(t *testing.T) {
+	config, err := loadSources([]byte(testConfig))
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(config.Generic.Dirs) != 1 || config.Generic.Dirs[0] != "/var/log" {
+		t.Errorf("Quotes should be stripped from dirs, got %v", config.Generic.Dirs)
+	}
+
+	if config.Generic.MaxDepth != 2 {
+		t.Errorf("Inline comment should be stripped from recursion, got %d", config.Generic.MaxDepth)
+	}
+}
+
+func TestLoadSkipsMissingFiles(t *testing.T) {
+	if _, err := loadSources("/nonexistent/logan.conf"); err != nil {
+		t.Errorf("Missing file caused error: %s", err)
+	}
+}
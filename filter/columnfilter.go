@@ -3,8 +3,10 @@ package filter
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/kbence/logan/types"
 )
@@ -15,30 +17,91 @@ const (
 	OpEquals Operator = iota
 	OpNotEquals
 	OpMatchesRegexp
+	OpNotMatchesRegexp
+	OpMatchesRegexpIgnoreCase
+	OpNotMatchesRegexpIgnoreCase
+	OpContains
+	OpIn
+	OpNotIn
+	OpWithin
+	OpLess
+	OpLessOrEqual
+	OpGreater
+	OpGreaterOrEqual
 	OpOr
 	OpAnd
+	OpNot
 )
 
-var operatorStrings = []string{"==", "!=", "OR", "AND"}
+var operatorStrings = []string{"==", "!=", "~=", "!~", "~*", "!~*", "contains", "in", "not in", "within", "<", "<=", ">", ">=", "OR", "AND", "NOT"}
 
 type ExpressionType uint8
 
 const (
 	TypeLiteral ExpressionType = iota
+	TypeNumber
 	TypeColumn
 	TypeRelation
 	TypeLogical
+	TypeFunction
+	TypeTimeField
+	TypeNamedColumn
 )
 
 type Expression struct {
-	Type    ExpressionType
-	Parent  *Expression
-	Left    *Expression
-	Right   *Expression
-	Op      Operator
-	Literal string
+	Type     ExpressionType
+	Parent   *Expression
+	Left     *Expression
+	Right    *Expression
+	Op       Operator
+	Literal  string
+	Number   float64
+	Column   int
+	Position int
+	Args     []*Expression
+	Function *Function
+
+	unit        unit
+	isQuantity  bool
+	set         *valueSet
+	network     *net.IPNet
+	regexp      *regexp.Regexp
+	regexpCache map[string]*regexp.Regexp
 }
 
+// valueSet holds the members of an `in (...)` relation, numbers are kept
+// separately so that they can be looked up by their value
+type valueSet struct {
+	members []string
+	strings map[string]bool
+	numbers map[float64]bool
+}
+
+func newValueSet() *valueSet {
+	return &valueSet{strings: map[string]bool{}, numbers: map[float64]bool{}}
+}
+
+func (s *valueSet) Contains(value string) bool {
+	if s.strings[value] {
+		return true
+	}
+
+	if len(s.numbers) > 0 {
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return err == nil && s.numbers[number]
+	}
+
+	return false
+}
+
+func (s *valueSet) String() string {
+	return fmt.Sprintf("(%s)", strings.Join(s.members, ", "))
+}
+
+// regexpCacheSize limits the number of patterns cached for regular
+// expressions built from column values
+const regexpCacheSize = 256
+
 func (e *Expression) String() string {
 	buf := bytes.NewBufferString("[Expression ")
 
@@ -51,16 +114,49 @@ func (e *Expression) String() string {
 		buf.WriteString(fmt.Sprintf("\"%s\"", e.Literal))
 		break
 
+	case TypeNumber:
+		buf.WriteString(e.Literal)
+		break
+
 	case TypeColumn:
 		buf.WriteString(fmt.Sprintf("$%s", e.Literal))
 		break
 
 	case TypeRelation:
+		if e.set != nil {
+			buf.WriteString(fmt.Sprintf("%s %s %s", e.Left.String(),
+				operatorStrings[int(e.Op)], e.set.String()))
+			break
+		}
+
 		buf.WriteString(fmt.Sprintf("%s %s %s", e.Left.String(),
 			operatorStrings[int(e.Op)], e.Right.String()))
 		break
 
+	case TypeTimeField:
+		buf.WriteString(fmt.Sprintf("@%s", e.Literal))
+		break
+
+	case TypeNamedColumn:
+		buf.WriteString(fmt.Sprintf("$%s", e.Literal))
+		break
+
+	case TypeFunction:
+		args := []string{}
+
+		for _, arg := range e.Args {
+			args = append(args, arg.String())
+		}
+
+		buf.WriteString(fmt.Sprintf("%s(%s)", e.Literal, strings.Join(args, ", ")))
+		break
+
 	case TypeLogical:
+		if e.Op == OpNot {
+			buf.WriteString(fmt.Sprintf("%s %s", operatorStrings[int(e.Op)], e.Left.String()))
+			break
+		}
+
 		buf.WriteString(fmt.Sprintf("%s %s %s", e.Left.String(),
 			operatorStrings[int(e.Op)], e.Right.String()))
 		break
@@ -84,14 +180,32 @@ func (e *Expression) GoUp() *Expression {
 	return e.Parent
 }
 
+// replaceChild swaps the child expression old to new, which is needed when
+// a node is pushed down by a binary operator inside a group or negation
+func (e *Expression) replaceChild(old, new *Expression) {
+	if e.Left == old {
+		e.Left = new
+	} else if e.Right == old {
+		e.Right = new
+	}
+
+	for i, arg := range e.Args {
+		if arg == old {
+			e.Args[i] = new
+		}
+	}
+}
+
 func (e *Expression) PushLeftGoRight(op Operator) *Expression {
 	newExpr := &Expression{Type: TypeLogical, Parent: e.Parent, Left: e, Op: op}
 	newExpr.Right = &Expression{Parent: newExpr}
 
 	if e.Parent != nil {
-		e.Parent.Right = newExpr
+		e.Parent.replaceChild(e, newExpr)
 	}
 
+	e.Parent = newExpr
+
 	return newExpr.Right
 }
 
@@ -99,51 +213,382 @@ func (e *Expression) SetType(t ExpressionType) {
 	e.Type = t
 }
 
+func (e *Expression) SetNot() {
+	e.SetType(TypeLogical)
+	e.Op = OpNot
+}
+
 func (e *Expression) SetColumn(columnId string) {
 	e.SetType(TypeColumn)
 	e.Literal = columnId
+
+	column, err := strconv.ParseInt(columnId, 10, 31)
+	if err != nil {
+		column = -1
+	}
+
+	e.Column = int(column)
+}
+
+// SetColumnName turns the expression into a reference to a named column,
+// e.g. a key path in JSON lines
+func (e *Expression) SetColumnName(name string) {
+	e.SetType(TypeNamedColumn)
+	e.Literal = name
+}
+
+// SetFreeText turns the expression into a relation that matches lines
+// containing the given text anywhere
+func (e *Expression) SetFreeText(text string, position int) {
+	e.SetType(TypeRelation)
+	e.Op = OpContains
+	e.Left = &Expression{Parent: e}
+	e.Left.SetColumn("0")
+	e.Right = &Expression{Parent: e, Position: position}
+	e.Right.SetString(text)
 }
 
 func (e *Expression) SetString(str string) {
 	e.SetType(TypeLiteral)
 	e.Literal = str
+	e.Number, e.unit, e.isQuantity = parseQuantity(str)
+}
+
+// SetNumber turns the expression into a numeric literal, which may have a
+// duration or size unit (e.g. 500ms or 1MB)
+func (e *Expression) SetNumber(number string) {
+	e.SetType(TypeNumber)
+	e.Literal = number
+	e.Number, e.unit, e.isQuantity = parseQuantity(number)
+}
+
+// SetTimeField turns the expression into a reference to a component of the
+// line's parsed date
+func (e *Expression) SetTimeField(name string) {
+	e.SetType(TypeTimeField)
+	e.Literal = name
+}
+
+// SetFunction turns the expression into a function call, the function
+// itself is looked up when the expression is compiled
+func (e *Expression) SetFunction(name string, position int) {
+	e.SetType(TypeFunction)
+	e.Literal = name
+	e.Position = position
+}
+
+// AddArgument appends a new argument to a function call and returns it
+func (e *Expression) AddArgument() *Expression {
+	arg := &Expression{Parent: e}
+	e.Args = append(e.Args, arg)
+	return arg
+}
+
+// SetSet turns the expression into a set membership relation, members are
+// added by AddToSet and AddNumberToSet
+func (e *Expression) SetSet(op Operator) {
+	e.SetType(TypeRelation)
+	e.Op = op
+	e.set = newValueSet()
+}
+
+func (e *Expression) AddToSet(value string) {
+	e.set.members = append(e.set.members, fmt.Sprintf("\"%s\"", value))
+	e.set.strings[value] = true
+}
+
+func (e *Expression) AddNumberToSet(value string) {
+	number, _ := strconv.ParseFloat(value, 64)
+	e.set.members = append(e.set.members, value)
+	e.set.numbers[number] = true
 }
 
 func (e *Expression) EvaluateString(line *types.LogLine) string {
 	switch e.Type {
-	case TypeLiteral:
+	case TypeLiteral, TypeNumber:
 		return e.Literal
 
 	case TypeColumn:
-		column, err := strconv.ParseInt(e.Literal, 10, 31)
-		if err != nil || int(column) > len(line.Columns) {
-			break
+		if e.Column == 0 {
+			return line.Text()
 		}
 
-		value, found := line.Columns[int(column)]
-		if !found {
-			value = ""
-		}
+		return line.Columns[e.Column]
 
+	case TypeNamedColumn:
+		value, _ := line.Field(e.Literal)
 		return value
+
+	case TypeTimeField:
+		return evaluateTimeField(e.Literal, line.Date)
+
+	case TypeFunction:
+		args := make([]string, len(e.Args))
+
+		for i, arg := range e.Args {
+			args[i] = arg.EvaluateString(line)
+		}
+
+		return e.Function.Call(args)
 	}
 
 	return ""
 }
 
+// compile prepares the expression tree for evaluation: it compiles regular
+// expressions with literal patterns so they are not compiled for every line
+// and resolves function calls. Condition tells whether the expression is
+// evaluated as a boolean.
+func (e *Expression) compile(condition bool) *SyntaxError {
+	if e == nil {
+		return nil
+	}
+
+	if e.isRegexpRelation() && e.Right.Type == TypeLiteral {
+		re, err := regexp.Compile(e.regexpPattern(e.Right.Literal))
+
+		if err != nil {
+			return &SyntaxError{Position: e.Right.Position, Reason: fmt.Sprintf("invalid regular expression (%s)", err)}
+		}
+
+		e.regexp = re
+	}
+
+	if e.Type == TypeNumber && !e.isQuantity {
+		return &SyntaxError{Position: e.Position, Reason: fmt.Sprintf("invalid quantity %s", e.Literal)}
+	}
+
+	if e.Type == TypeRelation && e.Op == OpWithin && e.Right.Type == TypeLiteral {
+		network, err := parseNetwork(e.Right.Literal)
+
+		if err != nil {
+			return &SyntaxError{Position: e.Right.Position, Reason: fmt.Sprintf("invalid network %q", e.Right.Literal)}
+		}
+
+		e.network = network
+	}
+
+	if e.Type == TypeFunction {
+		if err := e.compileFunction(condition); err != nil {
+			return err
+		}
+	}
+
+	if err := e.Left.compile(e.Type == TypeLogical); err != nil {
+		return err
+	}
+
+	return e.Right.compile(e.Type == TypeLogical)
+}
+
+func (e *Expression) compileFunction(condition bool) *SyntaxError {
+	function, found := functions[e.Literal]
+
+	if !found {
+		return &SyntaxError{Position: e.Position, Reason: fmt.Sprintf("unknown function %s()", e.Literal)}
+	}
+
+	if len(e.Args) < function.MinArgs || len(e.Args) > function.MaxArgs {
+		expected := fmt.Sprintf("%d to %d arguments", function.MinArgs, function.MaxArgs)
+
+		if function.MinArgs == function.MaxArgs {
+			expected = fmt.Sprintf("%d argument(s)", function.MinArgs)
+		}
+
+		return &SyntaxError{Position: e.Position,
+			Reason: fmt.Sprintf("function %s() expects %s, got %d", e.Literal, expected, len(e.Args))}
+	}
+
+	if condition && !function.Predicate {
+		return &SyntaxError{Position: e.Position,
+			Reason: fmt.Sprintf("function %s() cannot be used as a condition", e.Literal)}
+	}
+
+	e.Function = function
+
+	for _, arg := range e.Args {
+		if err := arg.compile(false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Expression) isRegexpRelation() bool {
+	if e.Type != TypeRelation {
+		return false
+	}
+
+	switch e.Op {
+	case OpMatchesRegexp, OpNotMatchesRegexp, OpMatchesRegexpIgnoreCase, OpNotMatchesRegexpIgnoreCase:
+		return true
+	}
+
+	return false
+}
+
+// regexpPattern returns the pattern to be compiled for the relation's
+// operator
+func (e *Expression) regexpPattern(pattern string) string {
+	if e.Op == OpMatchesRegexpIgnoreCase || e.Op == OpNotMatchesRegexpIgnoreCase {
+		return "(?i)" + pattern
+	}
+
+	return pattern
+}
+
+// dynamicRegexp returns the compiled version of a pattern coming from a
+// column, nil if it's invalid
+func (e *Expression) dynamicRegexp(pattern string) *regexp.Regexp {
+	if re, found := e.regexpCache[pattern]; found {
+		return re
+	}
+
+	if e.regexpCache == nil || len(e.regexpCache) >= regexpCacheSize {
+		e.regexpCache = map[string]*regexp.Regexp{}
+	}
+
+	re, err := regexp.Compile(e.regexpPattern(pattern))
+	if err != nil {
+		re = nil
+	}
+
+	e.regexpCache[pattern] = re
+
+	return re
+}
+
+// matchRegexp matches the left side against the pattern on the right, the
+// second return value is false if the pattern is invalid
+func (e *Expression) matchRegexp(line *types.LogLine) (bool, bool) {
+	re := e.regexp
+
+	if re == nil {
+		if re = e.dynamicRegexp(e.Right.EvaluateString(line)); re == nil {
+			return false, false
+		}
+	}
+
+	return re.MatchString(e.Left.EvaluateString(line)), true
+}
+
+// matchNetwork tells if the left side is an address inside the network on
+// the right, lines where any of them cannot be parsed don't match
+func (e *Expression) matchNetwork(line *types.LogLine) bool {
+	network := e.network
+
+	if network == nil {
+		var err error
+
+		if network, err = parseNetwork(e.Right.EvaluateString(line)); err != nil {
+			return false
+		}
+	}
+
+	ip := parseIP(e.Left.EvaluateString(line))
+	return ip != nil && network.Contains(ip)
+}
+
+// evaluateQuantity returns the value of the expression as a quantity, the
+// last return value is false if it cannot be interpreted as one
+func (e *Expression) evaluateQuantity(line *types.LogLine) (float64, unit, bool) {
+	switch e.Type {
+	case TypeNumber:
+		return e.Number, e.unit, true
+
+	case TypeLiteral:
+		return e.Number, e.unit, e.isQuantity
+	}
+
+	return parseQuantity(e.EvaluateString(line))
+}
+
+// compareQuantities compares both sides of a relation by their value,
+// returns false as the second value if any of them is not a quantity or
+// their units differ
+func (e *Expression) compareQuantities(line *types.LogLine) (int, bool) {
+	left, leftUnit, ok := e.Left.evaluateQuantity(line)
+	if !ok {
+		return 0, false
+	}
+
+	right, rightUnit, ok := e.Right.evaluateQuantity(line)
+	if !ok || leftUnit != rightUnit {
+		return 0, false
+	}
+
+	switch {
+	case left < right:
+		return -1, true
+	case left > right:
+		return 1, true
+	}
+
+	return 0, true
+}
+
+// isNumeric tells if any side of the relation is a number literal, in which
+// case equality is checked numerically
+func (e *Expression) isNumeric() bool {
+	return e.Left.Type == TypeNumber || e.Right.Type == TypeNumber
+}
+
 func (e *Expression) EvaluateBool(line *types.LogLine) bool {
 	switch e.Type {
 	case TypeRelation:
 		switch e.Op {
 		case OpEquals:
+			if e.isNumeric() {
+				cmp, ok := e.compareQuantities(line)
+				return ok && cmp == 0
+			}
+
 			return e.Left.EvaluateString(line) == e.Right.EvaluateString(line)
 
 		case OpNotEquals:
+			if e.isNumeric() {
+				cmp, ok := e.compareQuantities(line)
+				return ok && cmp != 0
+			}
+
 			return e.Left.EvaluateString(line) != e.Right.EvaluateString(line)
 
-		case OpMatchesRegexp:
-			re := regexp.MustCompile(e.Right.EvaluateString(line))
-			return re.MatchString(e.Left.EvaluateString(line))
+		case OpLess:
+			cmp, ok := e.compareQuantities(line)
+			return ok && cmp < 0
+
+		case OpLessOrEqual:
+			cmp, ok := e.compareQuantities(line)
+			return ok && cmp <= 0
+
+		case OpGreater:
+			cmp, ok := e.compareQuantities(line)
+			return ok && cmp > 0
+
+		case OpGreaterOrEqual:
+			cmp, ok := e.compareQuantities(line)
+			return ok && cmp >= 0
+
+		case OpMatchesRegexp, OpMatchesRegexpIgnoreCase:
+			matches, ok := e.matchRegexp(line)
+			return ok && matches
+
+		case OpNotMatchesRegexp, OpNotMatchesRegexpIgnoreCase:
+			matches, ok := e.matchRegexp(line)
+			return ok && !matches
+
+		case OpContains:
+			return strings.Contains(e.Left.EvaluateString(line), e.Right.EvaluateString(line))
+
+		case OpIn:
+			return e.set.Contains(e.Left.EvaluateString(line))
+
+		case OpNotIn:
+			return !e.set.Contains(e.Left.EvaluateString(line))
+
+		case OpWithin:
+			return e.matchNetwork(line)
 		}
 		break
 
@@ -154,7 +599,13 @@ func (e *Expression) EvaluateBool(line *types.LogLine) bool {
 
 		case OpOr:
 			return e.Left.EvaluateBool(line) || e.Right.EvaluateBool(line)
+
+		case OpNot:
+			return !e.Left.EvaluateBool(line)
 		}
+
+	case TypeFunction:
+		return e.EvaluateString(line) == "true"
 	}
 
 	return false
@@ -164,13 +615,87 @@ type ColumnFilter struct {
 	expr *Expression
 }
 
-func NewColumnFilter(filterExpression string) *ColumnFilter {
+// SyntaxError is returned when a filter expression cannot be parsed or
+// compiled
+type SyntaxError struct {
+	Filter   string
+	Position int
+	Reason   string
+}
+
+func newSyntaxError(filterExpression string, err error) *SyntaxError {
+	position := 0
+
+	if parseErr, ok := err.(*parseError); ok {
+		position = int(parseErr.max.end)
+	}
+
+	return &SyntaxError{Filter: filterExpression, Position: position, Reason: "syntax error"}
+}
+
+func (e *SyntaxError) Error() string {
+	filterRunes := []rune(e.Filter)
+	position := e.Position
+
+	if position > len(filterRunes) {
+		position = len(filterRunes)
+	}
+
+	// Filters read from files may span multiple lines, only the line
+	// containing the error is shown
+	lineNumber, lineStart, lineEnd := 1, 0, len(filterRunes)
+
+	for i, r := range filterRunes {
+		if r != '\n' {
+			continue
+		}
+
+		if i < position {
+			lineNumber++
+			lineStart = i + 1
+		} else {
+			lineEnd = i
+			break
+		}
+	}
+
+	// Keep tabs in the padding so that the caret stays under the right column
+	padding := strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return r
+		}
+		return ' '
+	}, string(filterRunes[lineStart:position]))
+
+	location := fmt.Sprintf("column %d", position-lineStart+1)
+
+	if lineEnd < len(filterRunes) || lineStart > 0 {
+		location = fmt.Sprintf("line %d, %s", lineNumber, location)
+	}
+
+	return fmt.Sprintf("%s in filter at %s:\n    %s\n    %s^",
+		e.Reason, location, string(filterRunes[lineStart:lineEnd]), padding)
+}
+
+// NewColumnFilter parses and compiles the given filter expression, returns
+// a SyntaxError if it's invalid. The returned filter caches compiled regular
+// expressions, so it must not be used from multiple goroutines.
+func NewColumnFilter(filterExpression string) (*ColumnFilter, error) {
 	parser := &ColumnFilterParser{Buffer: filterExpression}
 	parser.Init()
-	parser.Parse()
+
+	if err := parser.Parse(); err != nil {
+		return nil, newSyntaxError(filterExpression, err)
+	}
+
 	parser.Execute()
 
-	return &ColumnFilter{expr: parser.Expr}
+	if err := parser.Expr.compile(true); err != nil {
+		err.Filter = filterExpression
+		return nil, err
+	}
+
+	return &ColumnFilter{expr: parser.Expr}, nil
 }
 
 func (f *ColumnFilter) String() string {
@@ -15,20 +15,46 @@ type pegRule uint8
 const (
 	ruleUnknown pegRule = iota
 	rulefilterExpression
+	ruleorFilterExpression
 	ruleandFilterExpression
+	rulenotFilterExpression
 	rulecolumnFilterExpression
 	rulerelation
+	rulesetRelation
+	rulesetOperator
+	ruleinOperator
+	rulenotInOperator
+	rulesetMember
+	rulepredicate
+	rulefreeText
 	ruleexpression
+	rulefunctionCall
+	rulefunctionArgument
 	rulecolumnSpecifier
+	rulecolumnName
+	ruletimeField
+	ruleidentifierChar
 	ruleliteral
 	rulestringLiteral
 	rulestringContent
+	rulenumberLiteral
+	rulenumber
+	ruleunitSuffix
 	rulerelationOperator
 	ruleequals
 	rulenotEquals
 	rulematchesRegexp
+	rulematchesRegexpIgnoreCase
+	rulenotMatchesRegexp
+	rulenotMatchesRegexpIgnoreCase
+	rulelessOrEqual
+	ruleless
+	rulegreaterOrEqual
+	rulegreater
+	rulewithin
 	ruleandOperator
 	ruleorOperator
+	rulenotOperator
 	rulews
 	ruleAction0
 	ruleAction1
@@ -40,31 +66,81 @@ const (
 	ruleAction7
 	ruleAction8
 	ruleAction9
-	rulePegText
 	ruleAction10
 	ruleAction11
 	ruleAction12
 	ruleAction13
 	ruleAction14
+	ruleAction15
+	ruleAction16
+	rulePegText
+	ruleAction17
+	ruleAction18
+	ruleAction19
+	ruleAction20
+	ruleAction21
+	ruleAction22
+	ruleAction23
+	ruleAction24
+	ruleAction25
+	ruleAction26
+	ruleAction27
+	ruleAction28
+	ruleAction29
+	ruleAction30
+	ruleAction31
+	ruleAction32
+	ruleAction33
+	ruleAction34
+	ruleAction35
+	ruleAction36
+	ruleAction37
+	ruleAction38
 )
 
 var rul3s = [...]string{
 	"Unknown",
 	"filterExpression",
+	"orFilterExpression",
 	"andFilterExpression",
+	"notFilterExpression",
 	"columnFilterExpression",
 	"relation",
+	"setRelation",
+	"setOperator",
+	"inOperator",
+	"notInOperator",
+	"setMember",
+	"predicate",
+	"freeText",
 	"expression",
+	"functionCall",
+	"functionArgument",
 	"columnSpecifier",
+	"columnName",
+	"timeField",
+	"identifierChar",
 	"literal",
 	"stringLiteral",
 	"stringContent",
+	"numberLiteral",
+	"number",
+	"unitSuffix",
 	"relationOperator",
 	"equals",
 	"notEquals",
 	"matchesRegexp",
+	"matchesRegexpIgnoreCase",
+	"notMatchesRegexp",
+	"notMatchesRegexpIgnoreCase",
+	"lessOrEqual",
+	"less",
+	"greaterOrEqual",
+	"greater",
+	"within",
 	"andOperator",
 	"orOperator",
+	"notOperator",
 	"ws",
 	"Action0",
 	"Action1",
@@ -76,12 +152,36 @@ var rul3s = [...]string{
 	"Action7",
 	"Action8",
 	"Action9",
-	"PegText",
 	"Action10",
 	"Action11",
 	"Action12",
 	"Action13",
 	"Action14",
+	"Action15",
+	"Action16",
+	"PegText",
+	"Action17",
+	"Action18",
+	"Action19",
+	"Action20",
+	"Action21",
+	"Action22",
+	"Action23",
+	"Action24",
+	"Action25",
+	"Action26",
+	"Action27",
+	"Action28",
+	"Action29",
+	"Action30",
+	"Action31",
+	"Action32",
+	"Action33",
+	"Action34",
+	"Action35",
+	"Action36",
+	"Action37",
+	"Action38",
 }
 
 type token32 struct {
@@ -198,7 +298,7 @@ type ColumnFilterParser struct {
 
 	Buffer string
 	buffer []rune
-	rules  [33]func() bool
+	rules  [83]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -301,25 +401,75 @@ func (p *ColumnFilterParser) Execute() {
 		case ruleAction4:
 			p.Expr = p.Expr.GoUp()
 		case ruleAction5:
-			p.Expr = p.Expr.GoLeft()
+			p.Expr.SetNot()
 		case ruleAction6:
-			p.Expr = p.Expr.GoUp()
+			p.Expr = p.Expr.GoLeft()
 		case ruleAction7:
-			p.Expr.SetType(TypeRelation)
+			p.Expr = p.Expr.GoUp()
 		case ruleAction8:
-			p.Expr = p.Expr.GoRight()
+			p.Expr = p.Expr.GoLeft()
 		case ruleAction9:
 			p.Expr = p.Expr.GoUp()
 		case ruleAction10:
-			p.Expr.SetColumn(buffer[begin:end])
+			p.Expr.SetType(TypeRelation)
 		case ruleAction11:
-			p.Expr.SetString(buffer[begin:end])
+			p.Expr = p.Expr.GoRight()
 		case ruleAction12:
-			p.Expr.Op = OpEquals
+			p.Expr = p.Expr.GoUp()
 		case ruleAction13:
-			p.Expr.Op = OpNotEquals
+			p.Expr = p.Expr.GoLeft()
 		case ruleAction14:
+			p.Expr = p.Expr.GoUp()
+		case ruleAction15:
+			p.Expr.SetSet(OpIn)
+		case ruleAction16:
+			p.Expr.SetSet(OpNotIn)
+		case ruleAction17:
+			p.Expr.AddToSet(buffer[begin:end])
+		case ruleAction18:
+			p.Expr.AddNumberToSet(buffer[begin:end])
+		case ruleAction19:
+			p.Expr.SetFreeText(buffer[begin:end], begin)
+		case ruleAction20:
+			p.Expr.SetFunction(buffer[begin:end], begin)
+		case ruleAction21:
+			p.Expr = p.Expr.AddArgument()
+		case ruleAction22:
+			p.Expr = p.Expr.GoUp()
+		case ruleAction23:
+			p.Expr.SetColumn(buffer[begin:end])
+		case ruleAction24:
+			p.Expr.SetColumnName(buffer[begin:end])
+		case ruleAction25:
+			p.Expr.SetTimeField(buffer[begin:end])
+		case ruleAction26:
+			p.Expr.SetString(buffer[begin:end])
+			p.Expr.Position = begin
+		case ruleAction27:
+			p.Expr.SetNumber(buffer[begin:end])
+			p.Expr.Position = begin
+		case ruleAction28:
+			p.Expr.Op = OpEquals
+		case ruleAction29:
+			p.Expr.Op = OpNotEquals
+		case ruleAction30:
 			p.Expr.Op = OpMatchesRegexp
+		case ruleAction31:
+			p.Expr.Op = OpMatchesRegexpIgnoreCase
+		case ruleAction32:
+			p.Expr.Op = OpNotMatchesRegexp
+		case ruleAction33:
+			p.Expr.Op = OpNotMatchesRegexpIgnoreCase
+		case ruleAction34:
+			p.Expr.Op = OpLessOrEqual
+		case ruleAction35:
+			p.Expr.Op = OpLess
+		case ruleAction36:
+			p.Expr.Op = OpGreaterOrEqual
+		case ruleAction37:
+			p.Expr.Op = OpGreater
+		case ruleAction38:
+			p.Expr.Op = OpWithin
 
 		}
 	}
@@ -394,7 +544,7 @@ func (p *ColumnFilterParser) Init() {
 
 	_rules = [...]func() bool{
 		nil,
-		/* 0 filterExpression <- <(Action0 andFilterExpression (ws+ orOperator ws+ Action1 andFilterExpression Action2)* !.)> */
+		/* 0 filterExpression <- <(Action0 ws* orFilterExpression ws* !.)> */
 		func() bool {
 			position0, tokenIndex0 := position, tokenIndex
 			{
@@ -402,62 +552,37 @@ func (p *ColumnFilterParser) Init() {
 				if !_rules[ruleAction0]() {
 					goto l0
 				}
-				if !_rules[ruleandFilterExpression]() {
-					goto l0
-				}
 			l2:
 				{
 					position3, tokenIndex3 := position, tokenIndex
 					if !_rules[rulews]() {
 						goto l3
 					}
-				l4:
-					{
-						position5, tokenIndex5 := position, tokenIndex
-						if !_rules[rulews]() {
-							goto l5
-						}
-						goto l4
-					l5:
-						position, tokenIndex = position5, tokenIndex5
-					}
-					if !_rules[ruleorOperator]() {
-						goto l3
-					}
-					if !_rules[rulews]() {
-						goto l3
-					}
-				l6:
-					{
-						position7, tokenIndex7 := position, tokenIndex
-						if !_rules[rulews]() {
-							goto l7
-						}
-						goto l6
-					l7:
-						position, tokenIndex = position7, tokenIndex7
-					}
-					if !_rules[ruleAction1]() {
-						goto l3
-					}
-					if !_rules[ruleandFilterExpression]() {
-						goto l3
-					}
-					if !_rules[ruleAction2]() {
-						goto l3
-					}
 					goto l2
 				l3:
 					position, tokenIndex = position3, tokenIndex3
 				}
+				if !_rules[ruleorFilterExpression]() {
+					goto l0
+				}
+			l4:
+				{
+					position5, tokenIndex5 := position, tokenIndex
+					if !_rules[rulews]() {
+						goto l5
+					}
+					goto l4
+				l5:
+					position, tokenIndex = position5, tokenIndex5
+				}
 				{
-					position8, tokenIndex8 := position, tokenIndex
+					position6, tokenIndex6 := position, tokenIndex
 					if !matchDot() {
-						goto l8
+						goto l6
 					}
 					goto l0
-				l8:
-					position, tokenIndex = position8, tokenIndex8
+				l6:
+					position, tokenIndex = position6, tokenIndex6
 				}
 				add(rulefilterExpression, position1)
 			}
@@ -466,668 +591,2377 @@ func (p *ColumnFilterParser) Init() {
 			position, tokenIndex = position0, tokenIndex0
 			return false
 		},
-		/* 1 andFilterExpression <- <(columnFilterExpression (ws+ andOperator ws+ Action3 columnFilterExpression Action4)*)> */
+		/* 1 orFilterExpression <- <(andFilterExpression (ws+ orOperator ws+ Action1 andFilterExpression Action2)*)> */
 		func() bool {
-			position9, tokenIndex9 := position, tokenIndex
+			position7, tokenIndex7 := position, tokenIndex
 			{
-				position10 := position
-				if !_rules[rulecolumnFilterExpression]() {
-					goto l9
+				position8 := position
+				if !_rules[ruleandFilterExpression]() {
+					goto l7
 				}
-			l11:
+			l9:
 				{
-					position12, tokenIndex12 := position, tokenIndex
+					position10, tokenIndex10 := position, tokenIndex
 					if !_rules[rulews]() {
-						goto l12
+						goto l10
 					}
-				l13:
+				l11:
 					{
-						position14, tokenIndex14 := position, tokenIndex
+						position12, tokenIndex12 := position, tokenIndex
 						if !_rules[rulews]() {
-							goto l14
+							goto l12
 						}
-						goto l13
-					l14:
-						position, tokenIndex = position14, tokenIndex14
+						goto l11
+					l12:
+						position, tokenIndex = position12, tokenIndex12
 					}
-					if !_rules[ruleandOperator]() {
-						goto l12
+					if !_rules[ruleorOperator]() {
+						goto l10
 					}
 					if !_rules[rulews]() {
-						goto l12
+						goto l10
 					}
-				l15:
+				l13:
 					{
-						position16, tokenIndex16 := position, tokenIndex
+						position14, tokenIndex14 := position, tokenIndex
 						if !_rules[rulews]() {
-							goto l16
+							goto l14
 						}
-						goto l15
-					l16:
-						position, tokenIndex = position16, tokenIndex16
+						goto l13
+					l14:
+						position, tokenIndex = position14, tokenIndex14
 					}
-					if !_rules[ruleAction3]() {
-						goto l12
+					if !_rules[ruleAction1]() {
+						goto l10
 					}
-					if !_rules[rulecolumnFilterExpression]() {
-						goto l12
+					if !_rules[ruleandFilterExpression]() {
+						goto l10
 					}
-					if !_rules[ruleAction4]() {
-						goto l12
+					if !_rules[ruleAction2]() {
+						goto l10
 					}
-					goto l11
-				l12:
-					position, tokenIndex = position12, tokenIndex12
-				}
-				add(ruleandFilterExpression, position10)
-			}
-			return true
-		l9:
-			position, tokenIndex = position9, tokenIndex9
-			return false
-		},
-		/* 2 columnFilterExpression <- <relation> */
-		func() bool {
-			position17, tokenIndex17 := position, tokenIndex
-			{
-				position18 := position
-				if !_rules[rulerelation]() {
-					goto l17
+					goto l9
+				l10:
+					position, tokenIndex = position10, tokenIndex10
 				}
-				add(rulecolumnFilterExpression, position18)
+				add(ruleorFilterExpression, position8)
 			}
 			return true
-		l17:
-			position, tokenIndex = position17, tokenIndex17
+		l7:
+			position, tokenIndex = position7, tokenIndex7
 			return false
 		},
-		/* 3 relation <- <(Action5 expression Action6 ws* Action7 relationOperator ws* Action8 expression Action9)> */
+		/* 2 andFilterExpression <- <(notFilterExpression (ws+ andOperator ws+ Action3 notFilterExpression Action4)*)> */
 		func() bool {
-			position19, tokenIndex19 := position, tokenIndex
+			position15, tokenIndex15 := position, tokenIndex
 			{
-				position20 := position
-				if !_rules[ruleAction5]() {
-					goto l19
+				position16 := position
+				if !_rules[rulenotFilterExpression]() {
+					goto l15
 				}
-				if !_rules[ruleexpression]() {
-					goto l19
-				}
-				if !_rules[ruleAction6]() {
-					goto l19
-				}
-			l21:
+			l17:
 				{
-					position22, tokenIndex22 := position, tokenIndex
+					position18, tokenIndex18 := position, tokenIndex
 					if !_rules[rulews]() {
-						goto l22
+						goto l18
+					}
+				l19:
+					{
+						position20, tokenIndex20 := position, tokenIndex
+						if !_rules[rulews]() {
+							goto l20
+						}
+						goto l19
+					l20:
+						position, tokenIndex = position20, tokenIndex20
+					}
+					if !_rules[ruleandOperator]() {
+						goto l18
 					}
-					goto l21
-				l22:
-					position, tokenIndex = position22, tokenIndex22
-				}
-				if !_rules[ruleAction7]() {
-					goto l19
-				}
-				if !_rules[rulerelationOperator]() {
-					goto l19
-				}
-			l23:
-				{
-					position24, tokenIndex24 := position, tokenIndex
 					if !_rules[rulews]() {
-						goto l24
+						goto l18
 					}
-					goto l23
-				l24:
-					position, tokenIndex = position24, tokenIndex24
-				}
-				if !_rules[ruleAction8]() {
-					goto l19
-				}
-				if !_rules[ruleexpression]() {
-					goto l19
-				}
-				if !_rules[ruleAction9]() {
-					goto l19
+				l21:
+					{
+						position22, tokenIndex22 := position, tokenIndex
+						if !_rules[rulews]() {
+							goto l22
+						}
+						goto l21
+					l22:
+						position, tokenIndex = position22, tokenIndex22
+					}
+					if !_rules[ruleAction3]() {
+						goto l18
+					}
+					if !_rules[rulenotFilterExpression]() {
+						goto l18
+					}
+					if !_rules[ruleAction4]() {
+						goto l18
+					}
+					goto l17
+				l18:
+					position, tokenIndex = position18, tokenIndex18
 				}
-				add(rulerelation, position20)
+				add(ruleandFilterExpression, position16)
 			}
 			return true
-		l19:
-			position, tokenIndex = position19, tokenIndex19
+		l15:
+			position, tokenIndex = position15, tokenIndex15
 			return false
 		},
-		/* 4 expression <- <(columnSpecifier / literal)> */
+		/* 3 notFilterExpression <- <((notOperator ws* Action5 Action6 notFilterExpression Action7) / columnFilterExpression)> */
 		func() bool {
-			position25, tokenIndex25 := position, tokenIndex
+			position23, tokenIndex23 := position, tokenIndex
 			{
-				position26 := position
+				position24 := position
 				{
-					position27, tokenIndex27 := position, tokenIndex
-					if !_rules[rulecolumnSpecifier]() {
-						goto l28
+					position25, tokenIndex25 := position, tokenIndex
+					if !_rules[rulenotOperator]() {
+						goto l26
 					}
-					goto l27
-				l28:
-					position, tokenIndex = position27, tokenIndex27
-					if !_rules[ruleliteral]() {
-						goto l25
+				l27:
+					{
+						position28, tokenIndex28 := position, tokenIndex
+						if !_rules[rulews]() {
+							goto l28
+						}
+						goto l27
+					l28:
+						position, tokenIndex = position28, tokenIndex28
+					}
+					if !_rules[ruleAction5]() {
+						goto l26
+					}
+					if !_rules[ruleAction6]() {
+						goto l26
+					}
+					if !_rules[rulenotFilterExpression]() {
+						goto l26
+					}
+					if !_rules[ruleAction7]() {
+						goto l26
+					}
+					goto l25
+				l26:
+					position, tokenIndex = position25, tokenIndex25
+					if !_rules[rulecolumnFilterExpression]() {
+						goto l23
 					}
 				}
-			l27:
-				add(ruleexpression, position26)
+			l25:
+				add(rulenotFilterExpression, position24)
 			}
 			return true
-		l25:
-			position, tokenIndex = position25, tokenIndex25
+		l23:
+			position, tokenIndex = position23, tokenIndex23
 			return false
 		},
-		/* 5 columnSpecifier <- <('$' <[0-9]+> Action10)> */
+		/* 4 columnFilterExpression <- <(('(' ws* orFilterExpression ws* ')') / relation / predicate / freeText)> */
 		func() bool {
 			position29, tokenIndex29 := position, tokenIndex
 			{
 				position30 := position
-				if buffer[position] != rune('$') {
-					goto l29
-				}
-				position++
 				{
-					position31 := position
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l29
+					position31, tokenIndex31 := position, tokenIndex
+					if buffer[position] != rune('(') {
+						goto l32
 					}
 					position++
-				l32:
+				l33:
 					{
-						position33, tokenIndex33 := position, tokenIndex
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l33
+						position34, tokenIndex34 := position, tokenIndex
+						if !_rules[rulews]() {
+							goto l34
 						}
-						position++
+						goto l33
+					l34:
+						position, tokenIndex = position34, tokenIndex34
+					}
+					if !_rules[ruleorFilterExpression]() {
 						goto l32
-					l33:
-						position, tokenIndex = position33, tokenIndex33
 					}
-					add(rulePegText, position31)
-				}
-				if !_rules[ruleAction10]() {
-					goto l29
+				l35:
+					{
+						position36, tokenIndex36 := position, tokenIndex
+						if !_rules[rulews]() {
+							goto l36
+						}
+						goto l35
+					l36:
+						position, tokenIndex = position36, tokenIndex36
+					}
+					if buffer[position] != rune(')') {
+						goto l32
+					}
+					position++
+					goto l31
+				l32:
+					position, tokenIndex = position31, tokenIndex31
+					if !_rules[rulerelation]() {
+						goto l37
+					}
+					goto l31
+				l37:
+					position, tokenIndex = position31, tokenIndex31
+					if !_rules[rulepredicate]() {
+						goto l38
+					}
+					goto l31
+				l38:
+					position, tokenIndex = position31, tokenIndex31
+					if !_rules[rulefreeText]() {
+						goto l29
+					}
 				}
-				add(rulecolumnSpecifier, position30)
+			l31:
+				add(rulecolumnFilterExpression, position30)
 			}
 			return true
 		l29:
 			position, tokenIndex = position29, tokenIndex29
 			return false
 		},
-		/* 6 literal <- <stringLiteral> */
-		func() bool {
-			position34, tokenIndex34 := position, tokenIndex
-			{
-				position35 := position
-				if !_rules[rulestringLiteral]() {
-					goto l34
-				}
-				add(ruleliteral, position35)
-			}
-			return true
-		l34:
-			position, tokenIndex = position34, tokenIndex34
-			return false
-		},
-		/* 7 stringLiteral <- <('"' <stringContent> '"' Action11)> */
-		func() bool {
-			position36, tokenIndex36 := position, tokenIndex
-			{
-				position37 := position
-				if buffer[position] != rune('"') {
-					goto l36
-				}
-				position++
-				{
-					position38 := position
-					if !_rules[rulestringContent]() {
-						goto l36
-					}
-					add(rulePegText, position38)
-				}
-				if buffer[position] != rune('"') {
-					goto l36
-				}
-				position++
-				if !_rules[ruleAction11]() {
-					goto l36
-				}
-				add(rulestringLiteral, position37)
-			}
-			return true
-		l36:
-			position, tokenIndex = position36, tokenIndex36
-			return false
-		},
-		/* 8 stringContent <- <((!'"' .) / ('\\' '"'))+> */
+		/* 5 relation <- <((Action8 expression Action9 ws* Action10 relationOperator ws* Action11 expression Action12) / setRelation)> */
 		func() bool {
 			position39, tokenIndex39 := position, tokenIndex
 			{
 				position40 := position
 				{
-					position43, tokenIndex43 := position, tokenIndex
+					position41, tokenIndex41 := position, tokenIndex
+					if !_rules[ruleAction8]() {
+						goto l42
+					}
+					if !_rules[ruleexpression]() {
+						goto l42
+					}
+					if !_rules[ruleAction9]() {
+						goto l42
+					}
+				l43:
 					{
-						position45, tokenIndex45 := position, tokenIndex
-						if buffer[position] != rune('"') {
-							goto l45
+						position44, tokenIndex44 := position, tokenIndex
+						if !_rules[rulews]() {
+							goto l44
 						}
-						position++
-						goto l44
-					l45:
-						position, tokenIndex = position45, tokenIndex45
-					}
-					if !matchDot() {
-						goto l44
+						goto l43
+					l44:
+						position, tokenIndex = position44, tokenIndex44
 					}
-					goto l43
-				l44:
-					position, tokenIndex = position43, tokenIndex43
-					if buffer[position] != rune('\\') {
-						goto l39
+					if !_rules[ruleAction10]() {
+						goto l42
 					}
-					position++
-					if buffer[position] != rune('"') {
-						goto l39
+					if !_rules[rulerelationOperator]() {
+						goto l42
 					}
-					position++
-				}
-			l43:
-			l41:
-				{
-					position42, tokenIndex42 := position, tokenIndex
+				l45:
 					{
 						position46, tokenIndex46 := position, tokenIndex
-						{
-							position48, tokenIndex48 := position, tokenIndex
-							if buffer[position] != rune('"') {
-								goto l48
-							}
-							position++
-							goto l47
-						l48:
-							position, tokenIndex = position48, tokenIndex48
-						}
-						if !matchDot() {
-							goto l47
+						if !_rules[rulews]() {
+							goto l46
 						}
-						goto l46
-					l47:
+						goto l45
+					l46:
 						position, tokenIndex = position46, tokenIndex46
-						if buffer[position] != rune('\\') {
-							goto l42
-						}
-						position++
-						if buffer[position] != rune('"') {
-							goto l42
-						}
-						position++
 					}
-				l46:
+					if !_rules[ruleAction11]() {
+						goto l42
+					}
+					if !_rules[ruleexpression]() {
+						goto l42
+					}
+					if !_rules[ruleAction12]() {
+						goto l42
+					}
 					goto l41
 				l42:
-					position, tokenIndex = position42, tokenIndex42
+					position, tokenIndex = position41, tokenIndex41
+					if !_rules[rulesetRelation]() {
+						goto l39
+					}
 				}
-				add(rulestringContent, position40)
+			l41:
+				add(rulerelation, position40)
 			}
 			return true
 		l39:
 			position, tokenIndex = position39, tokenIndex39
 			return false
 		},
-		/* 9 relationOperator <- <(equals / notEquals / matchesRegexp)> */
+		/* 6 setRelation <- <(Action13 expression Action14 ws+ setOperator ws* '(' ws* setMember (ws* ',' ws* setMember)* ws* ')')> */
 		func() bool {
-			position49, tokenIndex49 := position, tokenIndex
+			position47, tokenIndex47 := position, tokenIndex
 			{
-				position50 := position
+				position48 := position
+				if !_rules[ruleAction13]() {
+					goto l47
+				}
+				if !_rules[ruleexpression]() {
+					goto l47
+				}
+				if !_rules[ruleAction14]() {
+					goto l47
+				}
+				if !_rules[rulews]() {
+					goto l47
+				}
+			l49:
 				{
-					position51, tokenIndex51 := position, tokenIndex
-					if !_rules[ruleequals]() {
+					position50, tokenIndex50 := position, tokenIndex
+					if !_rules[rulews]() {
+						goto l50
+					}
+					goto l49
+				l50:
+					position, tokenIndex = position50, tokenIndex50
+				}
+				if !_rules[rulesetOperator]() {
+					goto l47
+				}
+			l51:
+				{
+					position52, tokenIndex52 := position, tokenIndex
+					if !_rules[rulews]() {
 						goto l52
 					}
 					goto l51
 				l52:
-					position, tokenIndex = position51, tokenIndex51
-					if !_rules[rulenotEquals]() {
-						goto l53
-					}
-					goto l51
-				l53:
-					position, tokenIndex = position51, tokenIndex51
-					if !_rules[rulematchesRegexp]() {
-						goto l49
-					}
+					position, tokenIndex = position52, tokenIndex52
 				}
-			l51:
-				add(rulerelationOperator, position50)
-			}
-			return true
-		l49:
-			position, tokenIndex = position49, tokenIndex49
-			return false
-		},
-		/* 10 equals <- <('=' '=' Action12)> */
-		func() bool {
-			position54, tokenIndex54 := position, tokenIndex
-			{
-				position55 := position
-				if buffer[position] != rune('=') {
-					goto l54
+				if buffer[position] != rune('(') {
+					goto l47
 				}
 				position++
-				if buffer[position] != rune('=') {
-					goto l54
+			l53:
+				{
+					position54, tokenIndex54 := position, tokenIndex
+					if !_rules[rulews]() {
+						goto l54
+					}
+					goto l53
+				l54:
+					position, tokenIndex = position54, tokenIndex54
 				}
-				position++
-				if !_rules[ruleAction12]() {
-					goto l54
+				if !_rules[rulesetMember]() {
+					goto l47
 				}
-				add(ruleequals, position55)
-			}
-			return true
-		l54:
-			position, tokenIndex = position54, tokenIndex54
-			return false
-		},
-		/* 11 notEquals <- <((('!' '=') / ('<' '>')) Action13)> */
-		func() bool {
-			position56, tokenIndex56 := position, tokenIndex
-			{
-				position57 := position
+			l55:
 				{
-					position58, tokenIndex58 := position, tokenIndex
-					if buffer[position] != rune('!') {
-						goto l59
-					}
-					position++
+					position56, tokenIndex56 := position, tokenIndex
+				l57:
+					{
+						position58, tokenIndex58 := position, tokenIndex
+						if !_rules[rulews]() {
+							goto l58
+						}
+						goto l57
+					l58:
+						position, tokenIndex = position58, tokenIndex58
+					}
+					if buffer[position] != rune(',') {
+						goto l56
+					}
+					position++
+				l59:
+					{
+						position60, tokenIndex60 := position, tokenIndex
+						if !_rules[rulews]() {
+							goto l60
+						}
+						goto l59
+					l60:
+						position, tokenIndex = position60, tokenIndex60
+					}
+					if !_rules[rulesetMember]() {
+						goto l56
+					}
+					goto l55
+				l56:
+					position, tokenIndex = position56, tokenIndex56
+				}
+			l61:
+				{
+					position62, tokenIndex62 := position, tokenIndex
+					if !_rules[rulews]() {
+						goto l62
+					}
+					goto l61
+				l62:
+					position, tokenIndex = position62, tokenIndex62
+				}
+				if buffer[position] != rune(')') {
+					goto l47
+				}
+				position++
+				add(rulesetRelation, position48)
+			}
+			return true
+		l47:
+			position, tokenIndex = position47, tokenIndex47
+			return false
+		},
+		/* 7 setOperator <- <(inOperator / notInOperator)> */
+		func() bool {
+			position63, tokenIndex63 := position, tokenIndex
+			{
+				position64 := position
+				{
+					position65, tokenIndex65 := position, tokenIndex
+					if !_rules[ruleinOperator]() {
+						goto l66
+					}
+					goto l65
+				l66:
+					position, tokenIndex = position65, tokenIndex65
+					if !_rules[rulenotInOperator]() {
+						goto l63
+					}
+				}
+			l65:
+				add(rulesetOperator, position64)
+			}
+			return true
+		l63:
+			position, tokenIndex = position63, tokenIndex63
+			return false
+		},
+		/* 8 inOperator <- <((('i' 'n') / ('I' 'N')) Action15)> */
+		func() bool {
+			position67, tokenIndex67 := position, tokenIndex
+			{
+				position68 := position
+				{
+					position69, tokenIndex69 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l70
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l70
+					}
+					position++
+					goto l69
+				l70:
+					position, tokenIndex = position69, tokenIndex69
+					if buffer[position] != rune('I') {
+						goto l67
+					}
+					position++
+					if buffer[position] != rune('N') {
+						goto l67
+					}
+					position++
+				}
+			l69:
+				if !_rules[ruleAction15]() {
+					goto l67
+				}
+				add(ruleinOperator, position68)
+			}
+			return true
+		l67:
+			position, tokenIndex = position67, tokenIndex67
+			return false
+		},
+		/* 9 notInOperator <- <((('n' 'o' 't') / ('N' 'O' 'T')) ws+ (('i' 'n') / ('I' 'N')) Action16)> */
+		func() bool {
+			position71, tokenIndex71 := position, tokenIndex
+			{
+				position72 := position
+				{
+					position73, tokenIndex73 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l74
+					}
+					position++
+					if buffer[position] != rune('o') {
+						goto l74
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l74
+					}
+					position++
+					goto l73
+				l74:
+					position, tokenIndex = position73, tokenIndex73
+					if buffer[position] != rune('N') {
+						goto l71
+					}
+					position++
+					if buffer[position] != rune('O') {
+						goto l71
+					}
+					position++
+					if buffer[position] != rune('T') {
+						goto l71
+					}
+					position++
+				}
+			l73:
+				if !_rules[rulews]() {
+					goto l71
+				}
+			l75:
+				{
+					position76, tokenIndex76 := position, tokenIndex
+					if !_rules[rulews]() {
+						goto l76
+					}
+					goto l75
+				l76:
+					position, tokenIndex = position76, tokenIndex76
+				}
+				{
+					position77, tokenIndex77 := position, tokenIndex
+					if buffer[position] != rune('i') {
+						goto l78
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l78
+					}
+					position++
+					goto l77
+				l78:
+					position, tokenIndex = position77, tokenIndex77
+					if buffer[position] != rune('I') {
+						goto l71
+					}
+					position++
+					if buffer[position] != rune('N') {
+						goto l71
+					}
+					position++
+				}
+			l77:
+				if !_rules[ruleAction16]() {
+					goto l71
+				}
+				add(rulenotInOperator, position72)
+			}
+			return true
+		l71:
+			position, tokenIndex = position71, tokenIndex71
+			return false
+		},
+		/* 10 setMember <- <(('"' <stringContent> '"' Action17) / (<number> Action18))> */
+		func() bool {
+			position79, tokenIndex79 := position, tokenIndex
+			{
+				position80 := position
+				{
+					position81, tokenIndex81 := position, tokenIndex
+					if buffer[position] != rune('"') {
+						goto l82
+					}
+					position++
+					{
+						position83 := position
+						if !_rules[rulestringContent]() {
+							goto l82
+						}
+						add(rulePegText, position83)
+					}
+					if buffer[position] != rune('"') {
+						goto l82
+					}
+					position++
+					if !_rules[ruleAction17]() {
+						goto l82
+					}
+					goto l81
+				l82:
+					position, tokenIndex = position81, tokenIndex81
+					{
+						position84 := position
+						if !_rules[rulenumber]() {
+							goto l79
+						}
+						add(rulePegText, position84)
+					}
+					if !_rules[ruleAction18]() {
+						goto l79
+					}
+				}
+			l81:
+				add(rulesetMember, position80)
+			}
+			return true
+		l79:
+			position, tokenIndex = position79, tokenIndex79
+			return false
+		},
+		/* 11 predicate <- <functionCall> */
+		func() bool {
+			position85, tokenIndex85 := position, tokenIndex
+			{
+				position86 := position
+				if !_rules[rulefunctionCall]() {
+					goto l85
+				}
+				add(rulepredicate, position86)
+			}
+			return true
+		l85:
+			position, tokenIndex = position85, tokenIndex85
+			return false
+		},
+		/* 12 freeText <- <('"' <stringContent> '"' Action19)> */
+		func() bool {
+			position87, tokenIndex87 := position, tokenIndex
+			{
+				position88 := position
+				if buffer[position] != rune('"') {
+					goto l87
+				}
+				position++
+				{
+					position89 := position
+					if !_rules[rulestringContent]() {
+						goto l87
+					}
+					add(rulePegText, position89)
+				}
+				if buffer[position] != rune('"') {
+					goto l87
+				}
+				position++
+				if !_rules[ruleAction19]() {
+					goto l87
+				}
+				add(rulefreeText, position88)
+			}
+			return true
+		l87:
+			position, tokenIndex = position87, tokenIndex87
+			return false
+		},
+		/* 13 expression <- <(functionCall / columnSpecifier / timeField / literal)> */
+		func() bool {
+			position90, tokenIndex90 := position, tokenIndex
+			{
+				position91 := position
+				{
+					position92, tokenIndex92 := position, tokenIndex
+					if !_rules[rulefunctionCall]() {
+						goto l93
+					}
+					goto l92
+				l93:
+					position, tokenIndex = position92, tokenIndex92
+					if !_rules[rulecolumnSpecifier]() {
+						goto l94
+					}
+					goto l92
+				l94:
+					position, tokenIndex = position92, tokenIndex92
+					if !_rules[ruletimeField]() {
+						goto l95
+					}
+					goto l92
+				l95:
+					position, tokenIndex = position92, tokenIndex92
+					if !_rules[ruleliteral]() {
+						goto l90
+					}
+				}
+			l92:
+				add(ruleexpression, position91)
+			}
+			return true
+		l90:
+			position, tokenIndex = position90, tokenIndex90
+			return false
+		},
+		/* 14 functionCall <- <(<([a-z] / '_')+> Action20 ws* '(' ws* (functionArgument (ws* ',' ws* functionArgument)*)? ws* ')')> */
+		func() bool {
+			position96, tokenIndex96 := position, tokenIndex
+			{
+				position97 := position
+				{
+					position98 := position
+					{
+						position101, tokenIndex101 := position, tokenIndex
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l102
+						}
+						position++
+						goto l101
+					l102:
+						position, tokenIndex = position101, tokenIndex101
+						if buffer[position] != rune('_') {
+							goto l96
+						}
+						position++
+					}
+				l101:
+				l99:
+					{
+						position100, tokenIndex100 := position, tokenIndex
+						{
+							position103, tokenIndex103 := position, tokenIndex
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l104
+							}
+							position++
+							goto l103
+						l104:
+							position, tokenIndex = position103, tokenIndex103
+							if buffer[position] != rune('_') {
+								goto l100
+							}
+							position++
+						}
+					l103:
+						goto l99
+					l100:
+						position, tokenIndex = position100, tokenIndex100
+					}
+					add(rulePegText, position98)
+				}
+				if !_rules[ruleAction20]() {
+					goto l96
+				}
+			l105:
+				{
+					position106, tokenIndex106 := position, tokenIndex
+					if !_rules[rulews]() {
+						goto l106
+					}
+					goto l105
+				l106:
+					position, tokenIndex = position106, tokenIndex106
+				}
+				if buffer[position] != rune('(') {
+					goto l96
+				}
+				position++
+			l107:
+				{
+					position108, tokenIndex108 := position, tokenIndex
+					if !_rules[rulews]() {
+						goto l108
+					}
+					goto l107
+				l108:
+					position, tokenIndex = position108, tokenIndex108
+				}
+				{
+					position109, tokenIndex109 := position, tokenIndex
+					if !_rules[rulefunctionArgument]() {
+						goto l109
+					}
+				l111:
+					{
+						position112, tokenIndex112 := position, tokenIndex
+					l113:
+						{
+							position114, tokenIndex114 := position, tokenIndex
+							if !_rules[rulews]() {
+								goto l114
+							}
+							goto l113
+						l114:
+							position, tokenIndex = position114, tokenIndex114
+						}
+						if buffer[position] != rune(',') {
+							goto l112
+						}
+						position++
+					l115:
+						{
+							position116, tokenIndex116 := position, tokenIndex
+							if !_rules[rulews]() {
+								goto l116
+							}
+							goto l115
+						l116:
+							position, tokenIndex = position116, tokenIndex116
+						}
+						if !_rules[rulefunctionArgument]() {
+							goto l112
+						}
+						goto l111
+					l112:
+						position, tokenIndex = position112, tokenIndex112
+					}
+					goto l110
+				l109:
+					position, tokenIndex = position109, tokenIndex109
+				}
+			l110:
+			l117:
+				{
+					position118, tokenIndex118 := position, tokenIndex
+					if !_rules[rulews]() {
+						goto l118
+					}
+					goto l117
+				l118:
+					position, tokenIndex = position118, tokenIndex118
+				}
+				if buffer[position] != rune(')') {
+					goto l96
+				}
+				position++
+				add(rulefunctionCall, position97)
+			}
+			return true
+		l96:
+			position, tokenIndex = position96, tokenIndex96
+			return false
+		},
+		/* 15 functionArgument <- <(Action21 expression Action22)> */
+		func() bool {
+			position119, tokenIndex119 := position, tokenIndex
+			{
+				position120 := position
+				if !_rules[ruleAction21]() {
+					goto l119
+				}
+				if !_rules[ruleexpression]() {
+					goto l119
+				}
+				if !_rules[ruleAction22]() {
+					goto l119
+				}
+				add(rulefunctionArgument, position120)
+			}
+			return true
+		l119:
+			position, tokenIndex = position119, tokenIndex119
+			return false
+		},
+		/* 16 columnSpecifier <- <(('$' <[0-9]+> Action23) / ('$' '.'? <columnName> Action24))> */
+		func() bool {
+			position121, tokenIndex121 := position, tokenIndex
+			{
+				position122 := position
+				{
+					position123, tokenIndex123 := position, tokenIndex
+					if buffer[position] != rune('$') {
+						goto l124
+					}
+					position++
+					{
+						position125 := position
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l124
+						}
+						position++
+					l126:
+						{
+							position127, tokenIndex127 := position, tokenIndex
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l127
+							}
+							position++
+							goto l126
+						l127:
+							position, tokenIndex = position127, tokenIndex127
+						}
+						add(rulePegText, position125)
+					}
+					if !_rules[ruleAction23]() {
+						goto l124
+					}
+					goto l123
+				l124:
+					position, tokenIndex = position123, tokenIndex123
+					if buffer[position] != rune('$') {
+						goto l121
+					}
+					position++
+					{
+						position128, tokenIndex128 := position, tokenIndex
+						if buffer[position] != rune('.') {
+							goto l128
+						}
+						position++
+						goto l129
+					l128:
+						position, tokenIndex = position128, tokenIndex128
+					}
+				l129:
+					{
+						position130 := position
+						if !_rules[rulecolumnName]() {
+							goto l121
+						}
+						add(rulePegText, position130)
+					}
+					if !_rules[ruleAction24]() {
+						goto l121
+					}
+				}
+			l123:
+				add(rulecolumnSpecifier, position122)
+			}
+			return true
+		l121:
+			position, tokenIndex = position121, tokenIndex121
+			return false
+		},
+		/* 17 columnName <- <(([a-z] / [A-Z] / '_' / '@') (identifierChar / ('.' / '@' / '-'))*)> */
+		func() bool {
+			position131, tokenIndex131 := position, tokenIndex
+			{
+				position132 := position
+				{
+					position133, tokenIndex133 := position, tokenIndex
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l134
+					}
+					position++
+					goto l133
+				l134:
+					position, tokenIndex = position133, tokenIndex133
+					if c := buffer[position]; c < rune('A') || c > rune('Z') {
+						goto l135
+					}
+					position++
+					goto l133
+				l135:
+					position, tokenIndex = position133, tokenIndex133
+					if buffer[position] != rune('_') {
+						goto l136
+					}
+					position++
+					goto l133
+				l136:
+					position, tokenIndex = position133, tokenIndex133
+					if buffer[position] != rune('@') {
+						goto l131
+					}
+					position++
+				}
+			l133:
+			l137:
+				{
+					position138, tokenIndex138 := position, tokenIndex
+					{
+						position139, tokenIndex139 := position, tokenIndex
+						if !_rules[ruleidentifierChar]() {
+							goto l140
+						}
+						goto l139
+					l140:
+						position, tokenIndex = position139, tokenIndex139
+						{
+							position141, tokenIndex141 := position, tokenIndex
+							if buffer[position] != rune('.') {
+								goto l142
+							}
+							position++
+							goto l141
+						l142:
+							position, tokenIndex = position141, tokenIndex141
+							if buffer[position] != rune('@') {
+								goto l143
+							}
+							position++
+							goto l141
+						l143:
+							position, tokenIndex = position141, tokenIndex141
+							if buffer[position] != rune('-') {
+								goto l138
+							}
+							position++
+						}
+					l141:
+					}
+				l139:
+					goto l137
+				l138:
+					position, tokenIndex = position138, tokenIndex138
+				}
+				add(rulecolumnName, position132)
+			}
+			return true
+		l131:
+			position, tokenIndex = position131, tokenIndex131
+			return false
+		},
+		/* 18 timeField <- <('@' <(('t' 'i' 'm' 'e') / ('d' 'a' 't' 'e') / ('h' 'o' 'u' 'r') / ('m' 'i' 'n' 'u' 't' 'e') / ('w' 'e' 'e' 'k' 'd' 'a' 'y'))> !identifierChar Action25)> */
+		func() bool {
+			position144, tokenIndex144 := position, tokenIndex
+			{
+				position145 := position
+				if buffer[position] != rune('@') {
+					goto l144
+				}
+				position++
+				{
+					position146 := position
+					{
+						position147, tokenIndex147 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l148
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l148
+						}
+						position++
+						if buffer[position] != rune('m') {
+							goto l148
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l148
+						}
+						position++
+						goto l147
+					l148:
+						position, tokenIndex = position147, tokenIndex147
+						if buffer[position] != rune('d') {
+							goto l149
+						}
+						position++
+						if buffer[position] != rune('a') {
+							goto l149
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l149
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l149
+						}
+						position++
+						goto l147
+					l149:
+						position, tokenIndex = position147, tokenIndex147
+						if buffer[position] != rune('h') {
+							goto l150
+						}
+						position++
+						if buffer[position] != rune('o') {
+							goto l150
+						}
+						position++
+						if buffer[position] != rune('u') {
+							goto l150
+						}
+						position++
+						if buffer[position] != rune('r') {
+							goto l150
+						}
+						position++
+						goto l147
+					l150:
+						position, tokenIndex = position147, tokenIndex147
+						if buffer[position] != rune('m') {
+							goto l151
+						}
+						position++
+						if buffer[position] != rune('i') {
+							goto l151
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l151
+						}
+						position++
+						if buffer[position] != rune('u') {
+							goto l151
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l151
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l151
+						}
+						position++
+						goto l147
+					l151:
+						position, tokenIndex = position147, tokenIndex147
+						if buffer[position] != rune('w') {
+							goto l144
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l144
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l144
+						}
+						position++
+						if buffer[position] != rune('k') {
+							goto l144
+						}
+						position++
+						if buffer[position] != rune('d') {
+							goto l144
+						}
+						position++
+						if buffer[position] != rune('a') {
+							goto l144
+						}
+						position++
+						if buffer[position] != rune('y') {
+							goto l144
+						}
+						position++
+					}
+				l147:
+					add(rulePegText, position146)
+				}
+				{
+					position152, tokenIndex152 := position, tokenIndex
+					if !_rules[ruleidentifierChar]() {
+						goto l152
+					}
+					goto l144
+				l152:
+					position, tokenIndex = position152, tokenIndex152
+				}
+				if !_rules[ruleAction25]() {
+					goto l144
+				}
+				add(ruletimeField, position145)
+			}
+			return true
+		l144:
+			position, tokenIndex = position144, tokenIndex144
+			return false
+		},
+		/* 19 identifierChar <- <([a-z] / [A-Z] / [0-9] / '_')> */
+		func() bool {
+			position153, tokenIndex153 := position, tokenIndex
+			{
+				position154 := position
+				{
+					position155, tokenIndex155 := position, tokenIndex
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l156
+					}
+					position++
+					goto l155
+				l156:
+					position, tokenIndex = position155, tokenIndex155
+					if c := buffer[position]; c < rune('A') || c > rune('Z') {
+						goto l157
+					}
+					position++
+					goto l155
+				l157:
+					position, tokenIndex = position155, tokenIndex155
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l158
+					}
+					position++
+					goto l155
+				l158:
+					position, tokenIndex = position155, tokenIndex155
+					if buffer[position] != rune('_') {
+						goto l153
+					}
+					position++
+				}
+			l155:
+				add(ruleidentifierChar, position154)
+			}
+			return true
+		l153:
+			position, tokenIndex = position153, tokenIndex153
+			return false
+		},
+		/* 20 literal <- <(stringLiteral / numberLiteral)> */
+		func() bool {
+			position159, tokenIndex159 := position, tokenIndex
+			{
+				position160 := position
+				{
+					position161, tokenIndex161 := position, tokenIndex
+					if !_rules[rulestringLiteral]() {
+						goto l162
+					}
+					goto l161
+				l162:
+					position, tokenIndex = position161, tokenIndex161
+					if !_rules[rulenumberLiteral]() {
+						goto l159
+					}
+				}
+			l161:
+				add(ruleliteral, position160)
+			}
+			return true
+		l159:
+			position, tokenIndex = position159, tokenIndex159
+			return false
+		},
+		/* 21 stringLiteral <- <('"' <stringContent> '"' Action26)> */
+		func() bool {
+			position163, tokenIndex163 := position, tokenIndex
+			{
+				position164 := position
+				if buffer[position] != rune('"') {
+					goto l163
+				}
+				position++
+				{
+					position165 := position
+					if !_rules[rulestringContent]() {
+						goto l163
+					}
+					add(rulePegText, position165)
+				}
+				if buffer[position] != rune('"') {
+					goto l163
+				}
+				position++
+				if !_rules[ruleAction26]() {
+					goto l163
+				}
+				add(rulestringLiteral, position164)
+			}
+			return true
+		l163:
+			position, tokenIndex = position163, tokenIndex163
+			return false
+		},
+		/* 22 stringContent <- <((!'"' .) / ('\\' '"'))+> */
+		func() bool {
+			position166, tokenIndex166 := position, tokenIndex
+			{
+				position167 := position
+				{
+					position170, tokenIndex170 := position, tokenIndex
+					{
+						position172, tokenIndex172 := position, tokenIndex
+						if buffer[position] != rune('"') {
+							goto l172
+						}
+						position++
+						goto l171
+					l172:
+						position, tokenIndex = position172, tokenIndex172
+					}
+					if !matchDot() {
+						goto l171
+					}
+					goto l170
+				l171:
+					position, tokenIndex = position170, tokenIndex170
+					if buffer[position] != rune('\\') {
+						goto l166
+					}
+					position++
+					if buffer[position] != rune('"') {
+						goto l166
+					}
+					position++
+				}
+			l170:
+			l168:
+				{
+					position169, tokenIndex169 := position, tokenIndex
+					{
+						position173, tokenIndex173 := position, tokenIndex
+						{
+							position175, tokenIndex175 := position, tokenIndex
+							if buffer[position] != rune('"') {
+								goto l175
+							}
+							position++
+							goto l174
+						l175:
+							position, tokenIndex = position175, tokenIndex175
+						}
+						if !matchDot() {
+							goto l174
+						}
+						goto l173
+					l174:
+						position, tokenIndex = position173, tokenIndex173
+						if buffer[position] != rune('\\') {
+							goto l169
+						}
+						position++
+						if buffer[position] != rune('"') {
+							goto l169
+						}
+						position++
+					}
+				l173:
+					goto l168
+				l169:
+					position, tokenIndex = position169, tokenIndex169
+				}
+				add(rulestringContent, position167)
+			}
+			return true
+		l166:
+			position, tokenIndex = position166, tokenIndex166
+			return false
+		},
+		/* 23 numberLiteral <- <(<(number unitSuffix?)> Action27)> */
+		func() bool {
+			position176, tokenIndex176 := position, tokenIndex
+			{
+				position177 := position
+				{
+					position178 := position
+					if !_rules[rulenumber]() {
+						goto l176
+					}
+					{
+						position179, tokenIndex179 := position, tokenIndex
+						if !_rules[ruleunitSuffix]() {
+							goto l179
+						}
+						goto l180
+					l179:
+						position, tokenIndex = position179, tokenIndex179
+					}
+				l180:
+					add(rulePegText, position178)
+				}
+				if !_rules[ruleAction27]() {
+					goto l176
+				}
+				add(rulenumberLiteral, position177)
+			}
+			return true
+		l176:
+			position, tokenIndex = position176, tokenIndex176
+			return false
+		},
+		/* 24 number <- <('-'? [0-9]+ ('.' [0-9]+)?)> */
+		func() bool {
+			position181, tokenIndex181 := position, tokenIndex
+			{
+				position182 := position
+				{
+					position183, tokenIndex183 := position, tokenIndex
+					if buffer[position] != rune('-') {
+						goto l183
+					}
+					position++
+					goto l184
+				l183:
+					position, tokenIndex = position183, tokenIndex183
+				}
+			l184:
+				if c := buffer[position]; c < rune('0') || c > rune('9') {
+					goto l181
+				}
+				position++
+			l185:
+				{
+					position186, tokenIndex186 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l186
+					}
+					position++
+					goto l185
+				l186:
+					position, tokenIndex = position186, tokenIndex186
+				}
+				{
+					position187, tokenIndex187 := position, tokenIndex
+					if buffer[position] != rune('.') {
+						goto l187
+					}
+					position++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l187
+					}
+					position++
+				l189:
+					{
+						position190, tokenIndex190 := position, tokenIndex
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l190
+						}
+						position++
+						goto l189
+					l190:
+						position, tokenIndex = position190, tokenIndex190
+					}
+					goto l188
+				l187:
+					position, tokenIndex = position187, tokenIndex187
+				}
+			l188:
+				add(rulenumber, position182)
+			}
+			return true
+		l181:
+			position, tokenIndex = position181, tokenIndex181
+			return false
+		},
+		/* 25 unitSuffix <- <([a-z] / [A-Z] / 'µ' / 'μ')+> */
+		func() bool {
+			position191, tokenIndex191 := position, tokenIndex
+			{
+				position192 := position
+				{
+					position195, tokenIndex195 := position, tokenIndex
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l196
+					}
+					position++
+					goto l195
+				l196:
+					position, tokenIndex = position195, tokenIndex195
+					if c := buffer[position]; c < rune('A') || c > rune('Z') {
+						goto l197
+					}
+					position++
+					goto l195
+				l197:
+					position, tokenIndex = position195, tokenIndex195
+					if buffer[position] != rune('µ') {
+						goto l198
+					}
+					position++
+					goto l195
+				l198:
+					position, tokenIndex = position195, tokenIndex195
+					if buffer[position] != rune('μ') {
+						goto l191
+					}
+					position++
+				}
+			l195:
+			l193:
+				{
+					position194, tokenIndex194 := position, tokenIndex
+					{
+						position199, tokenIndex199 := position, tokenIndex
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l200
+						}
+						position++
+						goto l199
+					l200:
+						position, tokenIndex = position199, tokenIndex199
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l201
+						}
+						position++
+						goto l199
+					l201:
+						position, tokenIndex = position199, tokenIndex199
+						if buffer[position] != rune('µ') {
+							goto l202
+						}
+						position++
+						goto l199
+					l202:
+						position, tokenIndex = position199, tokenIndex199
+						if buffer[position] != rune('μ') {
+							goto l194
+						}
+						position++
+					}
+				l199:
+					goto l193
+				l194:
+					position, tokenIndex = position194, tokenIndex194
+				}
+				add(ruleunitSuffix, position192)
+			}
+			return true
+		l191:
+			position, tokenIndex = position191, tokenIndex191
+			return false
+		},
+		/* 26 relationOperator <- <(equals / notMatchesRegexpIgnoreCase / notMatchesRegexp / notEquals / matchesRegexp / matchesRegexpIgnoreCase / lessOrEqual / less / greaterOrEqual / greater / within)> */
+		func() bool {
+			position203, tokenIndex203 := position, tokenIndex
+			{
+				position204 := position
+				{
+					position205, tokenIndex205 := position, tokenIndex
+					if !_rules[ruleequals]() {
+						goto l206
+					}
+					goto l205
+				l206:
+					position, tokenIndex = position205, tokenIndex205
+					if !_rules[rulenotMatchesRegexpIgnoreCase]() {
+						goto l207
+					}
+					goto l205
+				l207:
+					position, tokenIndex = position205, tokenIndex205
+					if !_rules[rulenotMatchesRegexp]() {
+						goto l208
+					}
+					goto l205
+				l208:
+					position, tokenIndex = position205, tokenIndex205
+					if !_rules[rulenotEquals]() {
+						goto l209
+					}
+					goto l205
+				l209:
+					position, tokenIndex = position205, tokenIndex205
+					if !_rules[rulematchesRegexp]() {
+						goto l210
+					}
+					goto l205
+				l210:
+					position, tokenIndex = position205, tokenIndex205
+					if !_rules[rulematchesRegexpIgnoreCase]() {
+						goto l211
+					}
+					goto l205
+				l211:
+					position, tokenIndex = position205, tokenIndex205
+					if !_rules[rulelessOrEqual]() {
+						goto l212
+					}
+					goto l205
+				l212:
+					position, tokenIndex = position205, tokenIndex205
+					if !_rules[ruleless]() {
+						goto l213
+					}
+					goto l205
+				l213:
+					position, tokenIndex = position205, tokenIndex205
+					if !_rules[rulegreaterOrEqual]() {
+						goto l214
+					}
+					goto l205
+				l214:
+					position, tokenIndex = position205, tokenIndex205
+					if !_rules[rulegreater]() {
+						goto l215
+					}
+					goto l205
+				l215:
+					position, tokenIndex = position205, tokenIndex205
+					if !_rules[rulewithin]() {
+						goto l203
+					}
+				}
+			l205:
+				add(rulerelationOperator, position204)
+			}
+			return true
+		l203:
+			position, tokenIndex = position203, tokenIndex203
+			return false
+		},
+		/* 27 equals <- <('=' '=' Action28)> */
+		func() bool {
+			position216, tokenIndex216 := position, tokenIndex
+			{
+				position217 := position
+				if buffer[position] != rune('=') {
+					goto l216
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l216
+				}
+				position++
+				if !_rules[ruleAction28]() {
+					goto l216
+				}
+				add(ruleequals, position217)
+			}
+			return true
+		l216:
+			position, tokenIndex = position216, tokenIndex216
+			return false
+		},
+		/* 28 notEquals <- <((('!' '=') / ('<' '>')) Action29)> */
+		func() bool {
+			position218, tokenIndex218 := position, tokenIndex
+			{
+				position219 := position
+				{
+					position220, tokenIndex220 := position, tokenIndex
+					if buffer[position] != rune('!') {
+						goto l221
+					}
+					position++
 					if buffer[position] != rune('=') {
-						goto l59
+						goto l221
 					}
 					position++
-					goto l58
-				l59:
-					position, tokenIndex = position58, tokenIndex58
+					goto l220
+				l221:
+					position, tokenIndex = position220, tokenIndex220
 					if buffer[position] != rune('<') {
-						goto l56
+						goto l218
 					}
 					position++
 					if buffer[position] != rune('>') {
-						goto l56
+						goto l218
 					}
 					position++
 				}
-			l58:
-				if !_rules[ruleAction13]() {
-					goto l56
+			l220:
+				if !_rules[ruleAction29]() {
+					goto l218
+				}
+				add(rulenotEquals, position219)
+			}
+			return true
+		l218:
+			position, tokenIndex = position218, tokenIndex218
+			return false
+		},
+		/* 29 matchesRegexp <- <('~' '=' Action30)> */
+		func() bool {
+			position222, tokenIndex222 := position, tokenIndex
+			{
+				position223 := position
+				if buffer[position] != rune('~') {
+					goto l222
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l222
+				}
+				position++
+				if !_rules[ruleAction30]() {
+					goto l222
+				}
+				add(rulematchesRegexp, position223)
+			}
+			return true
+		l222:
+			position, tokenIndex = position222, tokenIndex222
+			return false
+		},
+		/* 30 matchesRegexpIgnoreCase <- <('~' '*' Action31)> */
+		func() bool {
+			position224, tokenIndex224 := position, tokenIndex
+			{
+				position225 := position
+				if buffer[position] != rune('~') {
+					goto l224
+				}
+				position++
+				if buffer[position] != rune('*') {
+					goto l224
+				}
+				position++
+				if !_rules[ruleAction31]() {
+					goto l224
+				}
+				add(rulematchesRegexpIgnoreCase, position225)
+			}
+			return true
+		l224:
+			position, tokenIndex = position224, tokenIndex224
+			return false
+		},
+		/* 31 notMatchesRegexp <- <('!' '~' Action32)> */
+		func() bool {
+			position226, tokenIndex226 := position, tokenIndex
+			{
+				position227 := position
+				if buffer[position] != rune('!') {
+					goto l226
+				}
+				position++
+				if buffer[position] != rune('~') {
+					goto l226
+				}
+				position++
+				if !_rules[ruleAction32]() {
+					goto l226
+				}
+				add(rulenotMatchesRegexp, position227)
+			}
+			return true
+		l226:
+			position, tokenIndex = position226, tokenIndex226
+			return false
+		},
+		/* 32 notMatchesRegexpIgnoreCase <- <('!' '~' '*' Action33)> */
+		func() bool {
+			position228, tokenIndex228 := position, tokenIndex
+			{
+				position229 := position
+				if buffer[position] != rune('!') {
+					goto l228
+				}
+				position++
+				if buffer[position] != rune('~') {
+					goto l228
+				}
+				position++
+				if buffer[position] != rune('*') {
+					goto l228
+				}
+				position++
+				if !_rules[ruleAction33]() {
+					goto l228
 				}
-				add(rulenotEquals, position57)
+				add(rulenotMatchesRegexpIgnoreCase, position229)
 			}
 			return true
-		l56:
-			position, tokenIndex = position56, tokenIndex56
+		l228:
+			position, tokenIndex = position228, tokenIndex228
 			return false
 		},
-		/* 12 matchesRegexp <- <('~' '=' Action14)> */
+		/* 33 lessOrEqual <- <('<' '=' Action34)> */
 		func() bool {
-			position60, tokenIndex60 := position, tokenIndex
+			position230, tokenIndex230 := position, tokenIndex
 			{
-				position61 := position
-				if buffer[position] != rune('~') {
-					goto l60
+				position231 := position
+				if buffer[position] != rune('<') {
+					goto l230
 				}
 				position++
 				if buffer[position] != rune('=') {
-					goto l60
+					goto l230
 				}
 				position++
-				if !_rules[ruleAction14]() {
-					goto l60
+				if !_rules[ruleAction34]() {
+					goto l230
+				}
+				add(rulelessOrEqual, position231)
+			}
+			return true
+		l230:
+			position, tokenIndex = position230, tokenIndex230
+			return false
+		},
+		/* 34 less <- <('<' Action35)> */
+		func() bool {
+			position232, tokenIndex232 := position, tokenIndex
+			{
+				position233 := position
+				if buffer[position] != rune('<') {
+					goto l232
+				}
+				position++
+				if !_rules[ruleAction35]() {
+					goto l232
+				}
+				add(ruleless, position233)
+			}
+			return true
+		l232:
+			position, tokenIndex = position232, tokenIndex232
+			return false
+		},
+		/* 35 greaterOrEqual <- <('>' '=' Action36)> */
+		func() bool {
+			position234, tokenIndex234 := position, tokenIndex
+			{
+				position235 := position
+				if buffer[position] != rune('>') {
+					goto l234
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l234
+				}
+				position++
+				if !_rules[ruleAction36]() {
+					goto l234
+				}
+				add(rulegreaterOrEqual, position235)
+			}
+			return true
+		l234:
+			position, tokenIndex = position234, tokenIndex234
+			return false
+		},
+		/* 36 greater <- <('>' Action37)> */
+		func() bool {
+			position236, tokenIndex236 := position, tokenIndex
+			{
+				position237 := position
+				if buffer[position] != rune('>') {
+					goto l236
+				}
+				position++
+				if !_rules[ruleAction37]() {
+					goto l236
+				}
+				add(rulegreater, position237)
+			}
+			return true
+		l236:
+			position, tokenIndex = position236, tokenIndex236
+			return false
+		},
+		/* 37 within <- <((('w' 'i' 't' 'h' 'i' 'n') / ('W' 'I' 'T' 'H' 'I' 'N')) !identifierChar Action38)> */
+		func() bool {
+			position238, tokenIndex238 := position, tokenIndex
+			{
+				position239 := position
+				{
+					position240, tokenIndex240 := position, tokenIndex
+					if buffer[position] != rune('w') {
+						goto l241
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l241
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l241
+					}
+					position++
+					if buffer[position] != rune('h') {
+						goto l241
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l241
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l241
+					}
+					position++
+					goto l240
+				l241:
+					position, tokenIndex = position240, tokenIndex240
+					if buffer[position] != rune('W') {
+						goto l238
+					}
+					position++
+					if buffer[position] != rune('I') {
+						goto l238
+					}
+					position++
+					if buffer[position] != rune('T') {
+						goto l238
+					}
+					position++
+					if buffer[position] != rune('H') {
+						goto l238
+					}
+					position++
+					if buffer[position] != rune('I') {
+						goto l238
+					}
+					position++
+					if buffer[position] != rune('N') {
+						goto l238
+					}
+					position++
+				}
+			l240:
+				{
+					position242, tokenIndex242 := position, tokenIndex
+					if !_rules[ruleidentifierChar]() {
+						goto l242
+					}
+					goto l238
+				l242:
+					position, tokenIndex = position242, tokenIndex242
 				}
-				add(rulematchesRegexp, position61)
+				if !_rules[ruleAction38]() {
+					goto l238
+				}
+				add(rulewithin, position239)
 			}
 			return true
-		l60:
-			position, tokenIndex = position60, tokenIndex60
+		l238:
+			position, tokenIndex = position238, tokenIndex238
 			return false
 		},
-		/* 13 andOperator <- <(('a' 'n' 'd') / ('A' 'N' 'D') / ('&' '&'))> */
+		/* 38 andOperator <- <(('a' 'n' 'd') / ('A' 'N' 'D') / ('&' '&'))> */
 		func() bool {
-			position62, tokenIndex62 := position, tokenIndex
+			position243, tokenIndex243 := position, tokenIndex
 			{
-				position63 := position
+				position244 := position
 				{
-					position64, tokenIndex64 := position, tokenIndex
+					position245, tokenIndex245 := position, tokenIndex
 					if buffer[position] != rune('a') {
-						goto l65
+						goto l246
 					}
 					position++
 					if buffer[position] != rune('n') {
-						goto l65
+						goto l246
 					}
 					position++
 					if buffer[position] != rune('d') {
-						goto l65
+						goto l246
 					}
 					position++
-					goto l64
-				l65:
-					position, tokenIndex = position64, tokenIndex64
+					goto l245
+				l246:
+					position, tokenIndex = position245, tokenIndex245
 					if buffer[position] != rune('A') {
-						goto l66
+						goto l247
 					}
 					position++
 					if buffer[position] != rune('N') {
-						goto l66
+						goto l247
 					}
 					position++
 					if buffer[position] != rune('D') {
-						goto l66
+						goto l247
 					}
 					position++
-					goto l64
-				l66:
-					position, tokenIndex = position64, tokenIndex64
+					goto l245
+				l247:
+					position, tokenIndex = position245, tokenIndex245
 					if buffer[position] != rune('&') {
-						goto l62
+						goto l243
 					}
 					position++
 					if buffer[position] != rune('&') {
-						goto l62
+						goto l243
 					}
 					position++
 				}
-			l64:
-				add(ruleandOperator, position63)
+			l245:
+				add(ruleandOperator, position244)
 			}
 			return true
-		l62:
-			position, tokenIndex = position62, tokenIndex62
+		l243:
+			position, tokenIndex = position243, tokenIndex243
 			return false
 		},
-		/* 14 orOperator <- <(('o' 'r') / ('O' 'R') / ('|' '|'))> */
+		/* 39 orOperator <- <(('o' 'r') / ('O' 'R') / ('|' '|'))> */
 		func() bool {
-			position67, tokenIndex67 := position, tokenIndex
+			position248, tokenIndex248 := position, tokenIndex
 			{
-				position68 := position
+				position249 := position
 				{
-					position69, tokenIndex69 := position, tokenIndex
+					position250, tokenIndex250 := position, tokenIndex
 					if buffer[position] != rune('o') {
-						goto l70
+						goto l251
 					}
 					position++
 					if buffer[position] != rune('r') {
-						goto l70
+						goto l251
 					}
 					position++
-					goto l69
-				l70:
-					position, tokenIndex = position69, tokenIndex69
+					goto l250
+				l251:
+					position, tokenIndex = position250, tokenIndex250
 					if buffer[position] != rune('O') {
-						goto l71
+						goto l252
 					}
 					position++
 					if buffer[position] != rune('R') {
-						goto l71
+						goto l252
 					}
 					position++
-					goto l69
-				l71:
-					position, tokenIndex = position69, tokenIndex69
+					goto l250
+				l252:
+					position, tokenIndex = position250, tokenIndex250
 					if buffer[position] != rune('|') {
-						goto l67
+						goto l248
 					}
 					position++
 					if buffer[position] != rune('|') {
-						goto l67
+						goto l248
 					}
 					position++
 				}
-			l69:
-				add(ruleorOperator, position68)
+			l250:
+				add(ruleorOperator, position249)
 			}
 			return true
-		l67:
-			position, tokenIndex = position67, tokenIndex67
+		l248:
+			position, tokenIndex = position248, tokenIndex248
+			return false
+		},
+		/* 40 notOperator <- <(((('n' 'o' 't') / ('N' 'O' 'T')) &(ws / '(')) / '!')> */
+		func() bool {
+			position253, tokenIndex253 := position, tokenIndex
+			{
+				position254 := position
+				{
+					position255, tokenIndex255 := position, tokenIndex
+					{
+						position257, tokenIndex257 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l258
+						}
+						position++
+						if buffer[position] != rune('o') {
+							goto l258
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l258
+						}
+						position++
+						goto l257
+					l258:
+						position, tokenIndex = position257, tokenIndex257
+						if buffer[position] != rune('N') {
+							goto l256
+						}
+						position++
+						if buffer[position] != rune('O') {
+							goto l256
+						}
+						position++
+						if buffer[position] != rune('T') {
+							goto l256
+						}
+						position++
+					}
+				l257:
+					{
+						position259, tokenIndex259 := position, tokenIndex
+						{
+							position260, tokenIndex260 := position, tokenIndex
+							if !_rules[rulews]() {
+								goto l261
+							}
+							goto l260
+						l261:
+							position, tokenIndex = position260, tokenIndex260
+							if buffer[position] != rune('(') {
+								goto l256
+							}
+							position++
+						}
+					l260:
+						position, tokenIndex = position259, tokenIndex259
+					}
+					goto l255
+				l256:
+					position, tokenIndex = position255, tokenIndex255
+					if buffer[position] != rune('!') {
+						goto l253
+					}
+					position++
+				}
+			l255:
+				add(rulenotOperator, position254)
+			}
+			return true
+		l253:
+			position, tokenIndex = position253, tokenIndex253
 			return false
 		},
-		/* 15 ws <- <(' ' / '\t')> */
+		/* 41 ws <- <(' ' / '\t' / '\n' / '\r')> */
 		func() bool {
-			position72, tokenIndex72 := position, tokenIndex
+			position262, tokenIndex262 := position, tokenIndex
 			{
-				position73 := position
+				position263 := position
 				{
-					position74, tokenIndex74 := position, tokenIndex
+					position264, tokenIndex264 := position, tokenIndex
 					if buffer[position] != rune(' ') {
-						goto l75
+						goto l265
 					}
 					position++
-					goto l74
-				l75:
-					position, tokenIndex = position74, tokenIndex74
+					goto l264
+				l265:
+					position, tokenIndex = position264, tokenIndex264
 					if buffer[position] != rune('\t') {
-						goto l72
+						goto l266
+					}
+					position++
+					goto l264
+				l266:
+					position, tokenIndex = position264, tokenIndex264
+					if buffer[position] != rune('\n') {
+						goto l267
+					}
+					position++
+					goto l264
+				l267:
+					position, tokenIndex = position264, tokenIndex264
+					if buffer[position] != rune('\r') {
+						goto l262
 					}
 					position++
 				}
-			l74:
-				add(rulews, position73)
+			l264:
+				add(rulews, position263)
 			}
 			return true
-		l72:
-			position, tokenIndex = position72, tokenIndex72
+		l262:
+			position, tokenIndex = position262, tokenIndex262
 			return false
 		},
-		/* 17 Action0 <- <{ p.Expr = &Expression{} }> */
+		/* 43 Action0 <- <{ p.Expr = &Expression{} }> */
 		func() bool {
 			{
 				add(ruleAction0, position)
 			}
 			return true
 		},
-		/* 18 Action1 <- <{ p.Expr = p.Expr.PushLeftGoRight(OpOr) }> */
+		/* 44 Action1 <- <{ p.Expr = p.Expr.PushLeftGoRight(OpOr) }> */
 		func() bool {
 			{
 				add(ruleAction1, position)
 			}
 			return true
 		},
-		/* 19 Action2 <- <{ p.Expr = p.Expr.GoUp() }> */
+		/* 45 Action2 <- <{ p.Expr = p.Expr.GoUp() }> */
 		func() bool {
 			{
 				add(ruleAction2, position)
 			}
 			return true
 		},
-		/* 20 Action3 <- <{ p.Expr = p.Expr.PushLeftGoRight(OpAnd) }> */
+		/* 46 Action3 <- <{ p.Expr = p.Expr.PushLeftGoRight(OpAnd) }> */
 		func() bool {
 			{
 				add(ruleAction3, position)
 			}
 			return true
 		},
-		/* 21 Action4 <- <{ p.Expr = p.Expr.GoUp() }> */
+		/* 47 Action4 <- <{ p.Expr = p.Expr.GoUp() }> */
 		func() bool {
 			{
 				add(ruleAction4, position)
 			}
 			return true
 		},
-		/* 22 Action5 <- <{ p.Expr = p.Expr.GoLeft() }> */
+		/* 48 Action5 <- <{ p.Expr.SetNot() }> */
 		func() bool {
 			{
 				add(ruleAction5, position)
 			}
 			return true
 		},
-		/* 23 Action6 <- <{ p.Expr = p.Expr.GoUp() }> */
+		/* 49 Action6 <- <{ p.Expr = p.Expr.GoLeft() }> */
 		func() bool {
 			{
 				add(ruleAction6, position)
 			}
 			return true
 		},
-		/* 24 Action7 <- <{ p.Expr.SetType(TypeRelation) }> */
+		/* 50 Action7 <- <{ p.Expr = p.Expr.GoUp() }> */
 		func() bool {
 			{
 				add(ruleAction7, position)
 			}
 			return true
 		},
-		/* 25 Action8 <- <{ p.Expr = p.Expr.GoRight() }> */
+		/* 51 Action8 <- <{ p.Expr = p.Expr.GoLeft() }> */
 		func() bool {
 			{
 				add(ruleAction8, position)
 			}
 			return true
 		},
-		/* 26 Action9 <- <{ p.Expr = p.Expr.GoUp() }> */
+		/* 52 Action9 <- <{ p.Expr = p.Expr.GoUp() }> */
 		func() bool {
 			{
 				add(ruleAction9, position)
 			}
 			return true
 		},
-		nil,
-		/* 28 Action10 <- <{ p.Expr.SetColumn(buffer[begin:end]) }> */
+		/* 53 Action10 <- <{ p.Expr.SetType(TypeRelation) }> */
 		func() bool {
 			{
 				add(ruleAction10, position)
 			}
 			return true
 		},
-		/* 29 Action11 <- <{ p.Expr.SetString(buffer[begin:end]) }> */
+		/* 54 Action11 <- <{ p.Expr = p.Expr.GoRight() }> */
 		func() bool {
 			{
 				add(ruleAction11, position)
 			}
 			return true
 		},
-		/* 30 Action12 <- <{ p.Expr.Op = OpEquals }> */
+		/* 55 Action12 <- <{ p.Expr = p.Expr.GoUp() }> */
 		func() bool {
 			{
 				add(ruleAction12, position)
 			}
 			return true
 		},
-		/* 31 Action13 <- <{ p.Expr.Op = OpNotEquals }> */
+		/* 56 Action13 <- <{ p.Expr = p.Expr.GoLeft() }> */
 		func() bool {
 			{
 				add(ruleAction13, position)
 			}
 			return true
 		},
-		/* 32 Action14 <- <{ p.Expr.Op = OpMatchesRegexp }> */
+		/* 57 Action14 <- <{ p.Expr = p.Expr.GoUp() }> */
 		func() bool {
 			{
 				add(ruleAction14, position)
 			}
 			return true
 		},
+		/* 58 Action15 <- <{ p.Expr.SetSet(OpIn) }> */
+		func() bool {
+			{
+				add(ruleAction15, position)
+			}
+			return true
+		},
+		/* 59 Action16 <- <{ p.Expr.SetSet(OpNotIn) }> */
+		func() bool {
+			{
+				add(ruleAction16, position)
+			}
+			return true
+		},
+		nil,
+		/* 61 Action17 <- <{ p.Expr.AddToSet(buffer[begin:end]) }> */
+		func() bool {
+			{
+				add(ruleAction17, position)
+			}
+			return true
+		},
+		/* 62 Action18 <- <{ p.Expr.AddNumberToSet(buffer[begin:end]) }> */
+		func() bool {
+			{
+				add(ruleAction18, position)
+			}
+			return true
+		},
+		/* 63 Action19 <- <{ p.Expr.SetFreeText(buffer[begin:end], begin) }> */
+		func() bool {
+			{
+				add(ruleAction19, position)
+			}
+			return true
+		},
+		/* 64 Action20 <- <{ p.Expr.SetFunction(buffer[begin:end], begin) }> */
+		func() bool {
+			{
+				add(ruleAction20, position)
+			}
+			return true
+		},
+		/* 65 Action21 <- <{ p.Expr = p.Expr.AddArgument() }> */
+		func() bool {
+			{
+				add(ruleAction21, position)
+			}
+			return true
+		},
+		/* 66 Action22 <- <{ p.Expr = p.Expr.GoUp() }> */
+		func() bool {
+			{
+				add(ruleAction22, position)
+			}
+			return true
+		},
+		/* 67 Action23 <- <{ p.Expr.SetColumn(buffer[begin:end]) }> */
+		func() bool {
+			{
+				add(ruleAction23, position)
+			}
+			return true
+		},
+		/* 68 Action24 <- <{ p.Expr.SetColumnName(buffer[begin:end]) }> */
+		func() bool {
+			{
+				add(ruleAction24, position)
+			}
+			return true
+		},
+		/* 69 Action25 <- <{ p.Expr.SetTimeField(buffer[begin:end]) }> */
+		func() bool {
+			{
+				add(ruleAction25, position)
+			}
+			return true
+		},
+		/* 70 Action26 <- <{ p.Expr.SetString(buffer[begin:end]); p.Expr.Position = begin }> */
+		func() bool {
+			{
+				add(ruleAction26, position)
+			}
+			return true
+		},
+		/* 71 Action27 <- <{ p.Expr.SetNumber(buffer[begin:end]); p.Expr.Position = begin }> */
+		func() bool {
+			{
+				add(ruleAction27, position)
+			}
+			return true
+		},
+		/* 72 Action28 <- <{ p.Expr.Op = OpEquals }> */
+		func() bool {
+			{
+				add(ruleAction28, position)
+			}
+			return true
+		},
+		/* 73 Action29 <- <{ p.Expr.Op = OpNotEquals }> */
+		func() bool {
+			{
+				add(ruleAction29, position)
+			}
+			return true
+		},
+		/* 74 Action30 <- <{ p.Expr.Op = OpMatchesRegexp }> */
+		func() bool {
+			{
+				add(ruleAction30, position)
+			}
+			return true
+		},
+		/* 75 Action31 <- <{ p.Expr.Op = OpMatchesRegexpIgnoreCase }> */
+		func() bool {
+			{
+				add(ruleAction31, position)
+			}
+			return true
+		},
+		/* 76 Action32 <- <{ p.Expr.Op = OpNotMatchesRegexp }> */
+		func() bool {
+			{
+				add(ruleAction32, position)
+			}
+			return true
+		},
+		/* 77 Action33 <- <{ p.Expr.Op = OpNotMatchesRegexpIgnoreCase }> */
+		func() bool {
+			{
+				add(ruleAction33, position)
+			}
+			return true
+		},
+		/* 78 Action34 <- <{ p.Expr.Op = OpLessOrEqual }> */
+		func() bool {
+			{
+				add(ruleAction34, position)
+			}
+			return true
+		},
+		/* 79 Action35 <- <{ p.Expr.Op = OpLess }> */
+		func() bool {
+			{
+				add(ruleAction35, position)
+			}
+			return true
+		},
+		/* 80 Action36 <- <{ p.Expr.Op = OpGreaterOrEqual }> */
+		func() bool {
+			{
+				add(ruleAction36, position)
+			}
+			return true
+		},
+		/* 81 Action37 <- <{ p.Expr.Op = OpGreater }> */
+		func() bool {
+			{
+				add(ruleAction37, position)
+			}
+			return true
+		},
+		/* 82 Action38 <- <{ p.Expr.Op = OpWithin }> */
+		func() bool {
+			{
+				add(ruleAction38, position)
+			}
+			return true
+		},
 	}
 	p.rules = _rules
 }
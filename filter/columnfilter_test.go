@@ -1,8 +1,12 @@
 package filter
 
 import (
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/kbence/logan/parser"
 	"github.com/kbence/logan/types"
 )
 
@@ -18,7 +22,23 @@ func newExpectation(matches bool, columns ...string) *expectation {
 		indexedColumns[i+1] = col
 	}
 
-	return &expectation{Matches: matches, Line: &types.LogLine{Columns: indexedColumns}}
+	line := &types.LogLine{Line: strings.Join(columns, " "), Columns: indexedColumns}
+
+	return &expectation{Matches: matches, Line: line}
+}
+
+func newDatedExpectation(matches bool, date time.Time) *expectation {
+	exp := newExpectation(matches)
+	exp.Line.Date = date
+	return exp
+}
+
+func expectMatchAt(date time.Time) *expectation {
+	return newDatedExpectation(true, date)
+}
+
+func expectDoesntMatchAt(date time.Time) *expectation {
+	return newDatedExpectation(false, date)
 }
 
 func expectMatch(columns ...string) *expectation {
@@ -30,7 +50,12 @@ func expectDoesntMatch(columns ...string) *expectation {
 }
 
 func testFilter(t *testing.T, filterString string, expectations ...*expectation) {
-	filter := NewColumnFilter(filterString)
+	filter, err := NewColumnFilter(filterString)
+
+	if err != nil {
+		t.Errorf("Filter '%s' couldn't be parsed: %s", filterString, err)
+		return
+	}
 
 	for _, exp := range expectations {
 		if exp.Matches {
@@ -63,6 +88,26 @@ func TestShouldNotPanicIfColumnIndexIsWrong(t *testing.T) {
 		expectDoesntMatch("", "", "teststring"))
 }
 
+func TestColumnsAfterMissingOnesCanBeReferenced(t *testing.T) {
+	line := &types.LogLine{Line: "x z", Columns: types.ColumnList{1: "x", 3: "z"}}
+
+	testFilter(t, `$3 == "z"`, &expectation{Matches: true, Line: line})
+	testFilter(t, `$2 != "y"`, &expectation{Matches: true, Line: line})
+}
+
+func TestColumnsAfterOptionalRegexGroupsCanBeReferenced(t *testing.T) {
+	format, err := parser.NewRegexFormat(`(?P<a>\w+)(?: (?P<b>\d+))? (?P<c>\w+) (?P<d>\w+)`, "", "", nil)
+
+	if err != nil {
+		t.Fatalf("Format couldn't be created: %s", err)
+	}
+
+	line := &types.LogLine{Line: "x y z"}
+	format.ParseColumns(line)
+
+	testFilter(t, `$4 == "z" and $d == "z" and $3 == "y"`, &expectation{Matches: true, Line: line})
+}
+
 func TestSimpleNotEqualsFilterWorks(t *testing.T) {
 	testFilter(t, "$3 != \"teststring\"",
 		expectDoesntMatch("", "", "teststring"),
@@ -103,3 +148,493 @@ func TestRegularExpressionWorks(t *testing.T) {
 		expectMatch("reverse matching$"),
 		expectDoesntMatch("weird idea"))
 }
+
+func TestNumericComparisonWorks(t *testing.T) {
+	testFilter(t, "$1 >= 500",
+		expectMatch("500"),
+		expectMatch("503"),
+		expectMatch("1024.5"),
+		expectDoesntMatch("404"),
+		expectDoesntMatch("-"))
+
+	testFilter(t, "$1 < 0.25",
+		expectMatch("0.1"),
+		expectMatch("-3"),
+		expectDoesntMatch("0.25"),
+		expectDoesntMatch("1"))
+
+	testFilter(t, "$1 <= -1.5",
+		expectMatch("-1.5"),
+		expectMatch("-20"),
+		expectDoesntMatch("-1"))
+
+	testFilter(t, "$1 > $2",
+		expectMatch("10", "9"),
+		expectDoesntMatch("9", "10"),
+		expectDoesntMatch("10", "nine"))
+}
+
+func TestNumericComparisonCoercesStringLiterals(t *testing.T) {
+	testFilter(t, "$9 >= \"500\"",
+		expectMatch("", "", "", "", "", "", "", "", "502"),
+		expectDoesntMatch("", "", "", "", "", "", "", "", "200"),
+		expectDoesntMatch("", "", "", "", "", "", "", "", "n/a"))
+}
+
+func TestEqualityWithNumberLiteralIsNumeric(t *testing.T) {
+	testFilter(t, "$1 == 200",
+		expectMatch("200"),
+		expectMatch("200.0"),
+		expectDoesntMatch("201"),
+		expectDoesntMatch("OK"))
+
+	testFilter(t, "$1 != 200",
+		expectMatch("201"),
+		expectDoesntMatch("OK"),
+		expectDoesntMatch("-"),
+		expectDoesntMatch("200"))
+}
+
+func TestNonFiniteValuesAreNotNumbers(t *testing.T) {
+	for _, filter := range []string{"$1 >= 500", "$1 <= 500", "$1 == 500", "$1 != 500"} {
+		testFilter(t, filter,
+			expectDoesntMatch("NaN"),
+			expectDoesntMatch("nan"),
+			expectDoesntMatch("Inf"),
+			expectDoesntMatch("-Infinity"))
+	}
+}
+
+func TestNotNegatesExpression(t *testing.T) {
+	testFilter(t, "NOT $1 == \"1\"",
+		expectMatch("2"),
+		expectDoesntMatch("1"))
+
+	testFilter(t, "!$1 == \"1\"",
+		expectMatch("2"),
+		expectDoesntMatch("1"))
+
+	testFilter(t, "not not $1 == \"1\"",
+		expectMatch("1"),
+		expectDoesntMatch("2"))
+}
+
+func TestNotBindsStrongerThanAnd(t *testing.T) {
+	testFilter(t, "NOT $1 == \"1\" AND $2 == \"2\"",
+		expectMatch("0", "2"),
+		expectDoesntMatch("1", "2"),
+		expectDoesntMatch("0", "0"))
+}
+
+func TestParenthesesOverrideOperatorPrecedence(t *testing.T) {
+	testFilter(t, "($1 == \"1\" OR $2 == \"second\") AND $3 == \"teststring\"",
+		expectMatch("1", "no match", "teststring"),
+		expectMatch("no match", "second", "teststring"),
+		expectDoesntMatch("1", "no match", "no match"),
+		expectDoesntMatch("no match", "no match", "teststring"))
+
+	testFilter(t, "$3 == \"teststring\" AND ($1 == \"1\" OR $2 == \"second\")",
+		expectMatch("1", "no match", "teststring"),
+		expectDoesntMatch("1", "no match", "no match"))
+}
+
+func TestNestedParenthesesWork(t *testing.T) {
+	testFilter(t, "( ( $1 == \"a\" OR ($1 == \"b\" AND $2 == \"c\") ) AND NOT ($3 == \"x\") )",
+		expectMatch("a", "", ""),
+		expectMatch("b", "c", ""),
+		expectDoesntMatch("b", "d", ""),
+		expectDoesntMatch("a", "", "x"))
+}
+
+func TestNegatedGroup(t *testing.T) {
+	testFilter(t, "NOT ($5 ~= \"CRON\" OR $5 ~= \"systemd\")",
+		expectMatch("", "", "", "", "sshd[123]:"),
+		expectDoesntMatch("", "", "", "", "CRON[27049]:"),
+		expectDoesntMatch("", "", "", "", "systemd[1]:"))
+
+	testFilter(t, "!($1 == \"1\") OR $2 == \"2\"",
+		expectMatch("0", "0"),
+		expectMatch("1", "2"),
+		expectDoesntMatch("1", "0"))
+}
+
+func testSyntaxError(t *testing.T, filterString string, position int) {
+	_, err := NewColumnFilter(filterString)
+
+	if err == nil {
+		t.Errorf("Filter '%s' should have failed to parse", filterString)
+		return
+	}
+
+	syntaxErr, ok := err.(*SyntaxError)
+
+	if !ok {
+		t.Errorf("Filter '%s' returned %T instead of *SyntaxError", filterString, err)
+		return
+	}
+
+	if syntaxErr.Position != position {
+		t.Errorf("Syntax error of '%s' is at position %d, expected %d",
+			filterString, syntaxErr.Position, position)
+	}
+}
+
+func TestSyntaxErrorsAreReported(t *testing.T) {
+	testSyntaxError(t, "$5 = \"x\"", 3)
+	testSyntaxError(t, "$1 == \"1\" AND", 13)
+	testSyntaxError(t, "($1 == \"1\"", 10)
+	testSyntaxError(t, "$1 == \"1\" $2 == \"2\"", 10)
+	testSyntaxError(t, "", 0)
+}
+
+func TestSyntaxErrorMessagePointsToPosition(t *testing.T) {
+	_, err := NewColumnFilter("$5 = \"x\"")
+	expected := "syntax error in filter at column 4:\n    $5 = \"x\"\n       ^"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("Error message should be %q, got %q", expected, err)
+	}
+}
+
+func TestNegatedRegularExpressionWorks(t *testing.T) {
+	testFilter(t, "$1 !~ \"^test\"",
+		expectMatch("another teststring"),
+		expectDoesntMatch("teststring"))
+}
+
+func TestCaseInsensitiveRegularExpressionWorks(t *testing.T) {
+	testFilter(t, "$1 ~* \"^error\"",
+		expectMatch("ERROR"),
+		expectMatch("Error:"),
+		expectDoesntMatch("warning"))
+
+	testFilter(t, "$1 !~* \"^error\"",
+		expectMatch("warning"),
+		expectDoesntMatch("ERROR"),
+		expectDoesntMatch("error"))
+
+	testFilter(t, "$1 ~* $2",
+		expectMatch("ERROR", "error"),
+		expectDoesntMatch("ERROR", "warn"))
+}
+
+func TestZeroColumnIsTheWholeLine(t *testing.T) {
+	testFilter(t, "$0 ~= \"connection (reset|refused)\"",
+		expectMatch("error:", "connection", "reset", "by", "peer"),
+		expectDoesntMatch("error:", "connection", "timed", "out"))
+
+	testFilter(t, "$0 == \"first second\"",
+		expectMatch("first", "second"),
+		expectDoesntMatch("first"))
+}
+
+func TestFreeTextSearchMatchesAnywhereInLine(t *testing.T) {
+	testFilter(t, "\"timeout\"",
+		expectMatch("upstream", "timeout", "while", "reading"),
+		expectMatch("read_timeout=5"),
+		expectDoesntMatch("upstream", "closed", "connection"))
+
+	testFilter(t, "\"time out\" AND $1 == \"ERROR\"",
+		expectMatch("ERROR", "time", "out"),
+		expectDoesntMatch("INFO", "time", "out"),
+		expectDoesntMatch("ERROR", "timeout"))
+
+	testFilter(t, "NOT \"healthcheck\"",
+		expectMatch("GET", "/api"),
+		expectDoesntMatch("GET", "/healthcheck"))
+}
+
+func TestFiltersCanSpanMultipleLines(t *testing.T) {
+	testFilter(t, "$1 == \"1\"\n  AND (\n    $2 == \"2\" OR\r\n    $3 == \"3\"\n  )\n",
+		expectMatch("1", "2", ""),
+		expectMatch("1", "", "3"),
+		expectDoesntMatch("1", "", ""))
+}
+
+func TestSyntaxErrorMessageShowsOnlyTheLineOfTheError(t *testing.T) {
+	_, err := NewColumnFilter("$1 == \"1\"\nAND $2 = \"2\"\nOR $3 == \"3\"")
+	expected := "syntax error in filter at line 2, column 8:\n    AND $2 = \"2\"\n           ^"
+
+	if err == nil || err.Error() != expected {
+		t.Errorf("Error message should be %q, got %q", expected, err)
+	}
+}
+
+func TestTimeFieldsCanBeCompared(t *testing.T) {
+	testFilter(t, "@time >= \"08:00\" AND @time < \"09:00\"",
+		expectMatchAt(time.Date(2017, 2, 25, 8, 0, 0, 0, time.UTC)),
+		expectMatchAt(time.Date(2017, 2, 26, 8, 59, 59, 0, time.UTC)),
+		expectDoesntMatchAt(time.Date(2017, 2, 26, 9, 0, 0, 0, time.UTC)),
+		expectDoesntMatchAt(time.Date(2017, 2, 26, 7, 59, 0, 0, time.UTC)),
+		expectDoesntMatch())
+
+	testFilter(t, "@time > \"8:30:15\"",
+		expectMatchAt(time.Date(2017, 2, 25, 8, 30, 16, 0, time.UTC)),
+		expectDoesntMatchAt(time.Date(2017, 2, 25, 8, 30, 15, 0, time.UTC)))
+}
+
+func TestTimeFieldsCanBeUsedInSets(t *testing.T) {
+	testFilter(t, "@hour in (2,3,4)",
+		expectMatchAt(time.Date(2017, 2, 25, 2, 10, 0, 0, time.UTC)),
+		expectMatchAt(time.Date(2017, 2, 25, 4, 59, 0, 0, time.UTC)),
+		expectDoesntMatchAt(time.Date(2017, 2, 25, 5, 0, 0, 0, time.UTC)))
+
+	testFilter(t, "@weekday in (\"Sat\", \"Sun\")",
+		expectMatchAt(time.Date(2017, 2, 25, 2, 10, 0, 0, time.UTC)),
+		expectMatchAt(time.Date(2017, 2, 26, 2, 10, 0, 0, time.UTC)),
+		expectDoesntMatchAt(time.Date(2017, 2, 27, 2, 10, 0, 0, time.UTC)))
+}
+
+func TestOtherTimeFieldsWork(t *testing.T) {
+	testFilter(t, "@weekday == \"Sat\" AND @date == \"2017-02-25\" AND @minute < 15",
+		expectMatchAt(time.Date(2017, 2, 25, 2, 10, 0, 0, time.UTC)),
+		expectDoesntMatchAt(time.Date(2017, 2, 25, 2, 15, 0, 0, time.UTC)),
+		expectDoesntMatchAt(time.Date(2017, 3, 4, 2, 10, 0, 0, time.UTC)))
+}
+
+func TestUnknownTimeFieldIsRejected(t *testing.T) {
+	testSyntaxError(t, "@timestamp > \"08:00\"", 6)
+}
+
+func TestSetMembershipWorks(t *testing.T) {
+	testFilter(t, "$12 in (\"500\",\"502\", \"503\")",
+		expectMatch("", "", "", "", "", "", "", "", "", "", "", "502"),
+		expectDoesntMatch("", "", "", "", "", "", "", "", "", "", "", "200"),
+		expectDoesntMatch("", "", "", "", "", "", "", "", "", "", "", "5020"))
+
+	testFilter(t, "$1 IN ( 2, 3, 4 ) AND $2 == \"x\"",
+		expectMatch("2", "x"),
+		expectMatch("4.0", "x"),
+		expectDoesntMatch("5", "x"),
+		expectDoesntMatch("3", "y"))
+
+	testFilter(t, "$1 in (\"GET\") OR $1 in (\"HEAD\")",
+		expectMatch("GET"),
+		expectMatch("HEAD"),
+		expectDoesntMatch("POST"))
+}
+
+func TestNegatedSetMembershipWorks(t *testing.T) {
+	testFilter(t, "$1 not in (\"200\", \"204\", 304)",
+		expectMatch("404"),
+		expectDoesntMatch("200"),
+		expectDoesntMatch("304"))
+
+	testFilter(t, "NOT $1 NOT IN (\"a\", \"b\")",
+		expectMatch("a"),
+		expectDoesntMatch("c"))
+}
+
+func TestSetMembershipSyntaxErrors(t *testing.T) {
+	testSyntaxError(t, "$1 in ()", 6)
+	testSyntaxError(t, "$1 in (\"a\",)", 10)
+	testSyntaxError(t, "$1 in (\"a\" \"b\")", 11)
+}
+
+func TestStringFunctionsCanBeUsedAsOperands(t *testing.T) {
+	testFilter(t, "lower($1) == \"error\"",
+		expectMatch("ERROR"),
+		expectMatch("Error"),
+		expectDoesntMatch("WARNING"))
+
+	testFilter(t, "len($1) > 3",
+		expectMatch("abcd"),
+		expectMatch("árvíz"),
+		expectDoesntMatch("abc"))
+
+	testFilter(t, "substr($1, 0, 10) == \"2017-02-26\"",
+		expectMatch("2017-02-26T08:00:05"),
+		expectDoesntMatch("2017-02-27T08:00:05"))
+
+	testFilter(t, "substr($1,4) == \"[27049]:\"",
+		expectMatch("CRON[27049]:"),
+		expectDoesntMatch("CRON"))
+
+	testFilter(t, "trim($5,\"[]:0123456789\") == \"CRON\"",
+		expectMatch("", "", "", "", "CRON[27049]:"),
+		expectDoesntMatch("", "", "", "", "systemd[1]:"))
+
+	testFilter(t, "trim(upper($1)) == \"GET\"",
+		expectMatch(" get "))
+}
+
+func TestPredicateFunctionsCanBeUsedAsConditions(t *testing.T) {
+	testFilter(t, "startswith($10,\"/api\")",
+		expectMatch("", "", "", "", "", "", "", "", "", "/api/v1/users"),
+		expectDoesntMatch("", "", "", "", "", "", "", "", "", "/static/app.js"))
+
+	testFilter(t, "contains($6, \"www\") AND NOT endswith($6, \".js\")",
+		expectMatch("", "", "", "", "", "www.example.com/"),
+		expectDoesntMatch("", "", "", "", "", "www.example.com/app.js"),
+		expectDoesntMatch("", "", "", "", "", "example.com/"))
+}
+
+func TestInvalidFunctionCallsAreRejected(t *testing.T) {
+	testSyntaxError(t, "nosuchfunc($1) == \"a\"", 0)
+	testSyntaxError(t, "$1 == substr($2)", 6)
+	testSyntaxError(t, "lower($1)", 0)
+	testSyntaxError(t, "$1 == \"a\" AND len($2)", 14)
+}
+
+func TestRegularExpressionFromColumnWorks(t *testing.T) {
+	testFilter(t, "$1 ~= $2",
+		expectMatch("teststring", "^test"),
+		expectMatch("another teststring", "string$"),
+		expectDoesntMatch("teststring", "^string"),
+		expectDoesntMatch("teststring", "(invalid"))
+}
+
+func TestInvalidRegularExpressionIsRejected(t *testing.T) {
+	testSyntaxError(t, "$1 ~= \"(unclosed\"", 7)
+	testSyntaxError(t, "$1 !~* \"(unclosed\"", 8)
+}
+
+func TestAddressesCanBeMatchedAgainstNetworks(t *testing.T) {
+	testFilter(t, "$1 within \"10.0.0.0/8\"",
+		expectMatch("10.1.2.3"),
+		expectMatch("10.255.255.255"),
+		expectDoesntMatch("11.0.0.1"),
+		expectDoesntMatch("10.1.2"),
+		expectDoesntMatch("-"))
+
+	testFilter(t, "$1 WITHIN \"2001:db8::/32\"",
+		expectMatch("2001:db8::1"),
+		expectMatch("[2001:db8:1::2]"),
+		expectDoesntMatch("2001:db9::1"),
+		expectDoesntMatch("10.1.2.3"))
+
+	testFilter(t, "$1 within \"192.168.1.1\"",
+		expectMatch("192.168.1.1"),
+		expectDoesntMatch("192.168.1.2"))
+
+	testFilter(t, "$1 within $2",
+		expectMatch("172.16.5.4", "172.16.0.0/16"),
+		expectDoesntMatch("172.17.5.4", "172.16.0.0/16"),
+		expectDoesntMatch("172.16.5.4", "not a network"))
+}
+
+func TestInvalidNetworkIsRejected(t *testing.T) {
+	testSyntaxError(t, "$1 within \"10.0.0.0/33\"", 11)
+	testSyntaxError(t, "$1 withinx \"10.0.0.0/8\"", 10)
+}
+
+func TestAddressFunctionsWork(t *testing.T) {
+	testFilter(t, "isip($1)",
+		expectMatch("127.0.0.1"),
+		expectMatch("::1"),
+		expectDoesntMatch("localhost"),
+		expectDoesntMatch(""))
+
+	testFilter(t, "isprivate($1)",
+		expectMatch("10.0.0.1"),
+		expectMatch("172.31.255.255"),
+		expectMatch("192.168.0.10"),
+		expectMatch("fd12:3456::1"),
+		expectDoesntMatch("172.32.0.1"),
+		expectDoesntMatch("8.8.8.8"),
+		expectDoesntMatch("2001:db8::1"),
+		expectDoesntMatch("-"))
+}
+
+func TestDurationsCanBeCompared(t *testing.T) {
+	testFilter(t, "$11 > 500ms",
+		expectMatch("", "", "", "", "", "", "", "", "", "", "523ms"),
+		expectMatch("", "", "", "", "", "", "", "", "", "", "1.2s"),
+		expectDoesntMatch("", "", "", "", "", "", "", "", "", "", "500ms"),
+		expectDoesntMatch("", "", "", "", "", "", "", "", "", "", "900µs"),
+		expectDoesntMatch("", "", "", "", "", "", "", "", "", "", "523"),
+		expectDoesntMatch("", "", "", "", "", "", "", "", "", "", "-"))
+
+	testFilter(t, "$1 == 1.5s",
+		expectMatch("1500ms"),
+		expectMatch("1s500ms"),
+		expectDoesntMatch("1.5"))
+
+	testFilter(t, "1.2s > 900ms", expectMatch())
+	testFilter(t, "\"2m\" > 90s", expectMatch())
+}
+
+func TestSizesCanBeCompared(t *testing.T) {
+	testFilter(t, "$10 >= 1MB",
+		expectMatch("", "", "", "", "", "", "", "", "", "1MB"),
+		expectMatch("", "", "", "", "", "", "", "", "", "1.5M"),
+		expectMatch("", "", "", "", "", "", "", "", "", "2048KiB"),
+		expectDoesntMatch("", "", "", "", "", "", "", "", "", "14KB"),
+		expectDoesntMatch("", "", "", "", "", "", "", "", "", "2000000"))
+
+	testFilter(t, "$1 < 1KB AND $1 > 100B",
+		expectMatch("512b"),
+		expectDoesntMatch("1k"))
+}
+
+func TestInvalidQuantitiesAreRejected(t *testing.T) {
+	testSyntaxError(t, "$1 > 5parsecs", 5)
+	testSyntaxError(t, "$1 == \"a\" OR $2 < 1.5XB", 18)
+}
+
+func TestNamedColumnsCanBeReferenced(t *testing.T) {
+	named := func(matches bool, names types.ColumnNames, columns ...string) *expectation {
+		exp := newExpectation(matches, columns...)
+		exp.Line.Names = names
+		return exp
+	}
+
+	testFilter(t, "$.request.status == 500 AND $level == \"error\"",
+		named(true, types.ColumnNames{"level": 1, "request.status": 2}, "error", "500"),
+		named(false, types.ColumnNames{"level": 1, "request.status": 2}, "info", "500"),
+		named(false, types.ColumnNames{"level": 1}, "error", "500"))
+
+	testFilter(t, "$@timestamp ~= \"^2017\" AND $user-agent != \"-\"",
+		named(true, types.ColumnNames{"@timestamp": 1, "user-agent": 2}, "2017-02-26", "curl"))
+}
+
+func TestWholeRecordIsMatched(t *testing.T) {
+	record := newExpectation(true, "ERROR", "failed")
+	record.Line.Continuation = []string{"java.lang.NullPointerException", "\tat Main.run(Main.java:10)"}
+
+	testFilter(t, "$0 ~= \"NullPointer\"", record, expectDoesntMatch("ERROR", "failed"))
+	testFilter(t, "\"Main.java\" AND $1 == \"ERROR\"", record)
+}
+
+var benchmarkLine = &types.LogLine{Columns: types.ColumnList{
+	1: "Mar", 2: "2", 3: "20:31:01", 4: "servername", 5: "CRON[27049]:",
+	6: "(www-data)", 7: "CMD", 8: "(/usr/local/bin/some_cronjob.sh )"}}
+
+func BenchmarkRegexpCompiledForEveryLine(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		regexp.MustCompile("^CRON\\[[0-9]+\\]:$").MatchString(benchmarkLine.Columns[5])
+	}
+}
+
+func BenchmarkRegexpFilterWithLiteralPattern(b *testing.B) {
+	filter, _ := NewColumnFilter("$5 ~= \"^CRON\\[[0-9]+\\]:$\"")
+
+	for i := 0; i < b.N; i++ {
+		filter.Match(benchmarkLine)
+	}
+}
+
+func BenchmarkRegexpFilterWithColumnPattern(b *testing.B) {
+	filter, _ := NewColumnFilter("$8 ~= $7")
+
+	for i := 0; i < b.N; i++ {
+		filter.Match(benchmarkLine)
+	}
+}
+
+func BenchmarkSetMembershipFilter(b *testing.B) {
+	filter, _ := NewColumnFilter("$3 in (\"20:31:00\", \"20:31:01\", \"20:31:02\", \"20:31:03\", \"20:31:04\")")
+
+	for i := 0; i < b.N; i++ {
+		filter.Match(benchmarkLine)
+	}
+}
+
+func BenchmarkChainedEqualityFilter(b *testing.B) {
+	filter, _ := NewColumnFilter("$3 == \"20:31:00\" OR $3 == \"20:31:02\" OR $3 == \"20:31:03\" OR " +
+		"$3 == \"20:31:04\" OR $3 == \"20:31:01\"")
+
+	for i := 0; i < b.N; i++ {
+		filter.Match(benchmarkLine)
+	}
+}
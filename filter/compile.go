@@ -0,0 +1,158 @@
+package filter
+
+import "sort"
+
+// Estimated evaluation costs of the different kinds of expressions, they
+// only need to be right relative to each other
+const (
+	costOperand       = 0
+	costTimeField     = 2
+	costFunction      = 5
+	costEquality      = 1
+	costSetMembership = 2
+	costQuantity      = 4
+	costContains      = 4
+	costNetwork       = 6
+	costRegexp        = 10
+	costDynamicRegexp = 20
+)
+
+// Compile parses all the filter expressions and merges them into a single
+// filter which matches lines matching every one of them. Operands of AND
+// and OR operators are reordered so that cheap checks are evaluated before
+// expensive ones (e.g. regular expressions). Returns nil if there are no
+// filter expressions.
+func Compile(filterExpressions []string) (*ColumnFilter, error) {
+	var expr *Expression
+
+	for _, filterExpression := range filterExpressions {
+		columnFilter, err := NewColumnFilter(filterExpression)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if expr == nil {
+			expr = columnFilter.expr
+			continue
+		}
+
+		expr = &Expression{Type: TypeLogical, Op: OpAnd, Left: expr, Right: columnFilter.expr}
+		expr.Left.Parent = expr
+		expr.Right.Parent = expr
+	}
+
+	if expr == nil {
+		return nil, nil
+	}
+
+	expr.optimize()
+
+	return &ColumnFilter{expr: expr}, nil
+}
+
+// cost returns the estimated cost of evaluating the expression
+func (e *Expression) cost() int {
+	if e == nil {
+		return 0
+	}
+
+	switch e.Type {
+	case TypeTimeField:
+		return costTimeField
+
+	case TypeFunction:
+		cost := costFunction
+
+		for _, arg := range e.Args {
+			cost += arg.cost()
+		}
+
+		return cost
+
+	case TypeLogical:
+		return e.Left.cost() + e.Right.cost()
+
+	case TypeRelation:
+		return e.relationCost() + e.Left.cost() + e.Right.cost()
+	}
+
+	return costOperand
+}
+
+func (e *Expression) relationCost() int {
+	switch e.Op {
+	case OpEquals, OpNotEquals:
+		if e.isNumeric() {
+			return costQuantity
+		}
+
+		return costEquality
+
+	case OpIn, OpNotIn:
+		return costSetMembership
+
+	case OpLess, OpLessOrEqual, OpGreater, OpGreaterOrEqual:
+		return costQuantity
+
+	case OpContains:
+		return costContains
+
+	case OpWithin:
+		return costNetwork
+	}
+
+	if e.isRegexpRelation() && e.regexp == nil {
+		return costDynamicRegexp
+	}
+
+	return costRegexp
+}
+
+// operands collects the operands of a chain of the same logical operator,
+// e.g. a, b and c from (a AND b) AND c
+func (e *Expression) operands(op Operator) []*Expression {
+	if e.Type != TypeLogical || e.Op != op {
+		return []*Expression{e}
+	}
+
+	return append(e.Left.operands(op), e.Right.operands(op)...)
+}
+
+// optimize reorders the operands of AND and OR operators by their
+// estimated cost, the order of operands with the same cost is kept
+func (e *Expression) optimize() {
+	if e == nil || e.Type != TypeLogical {
+		return
+	}
+
+	if e.Op == OpNot {
+		e.Left.optimize()
+		return
+	}
+
+	operands := e.operands(e.Op)
+
+	for _, operand := range operands {
+		operand.optimize()
+	}
+
+	sort.SliceStable(operands, func(i, j int) bool {
+		return operands[i].cost() < operands[j].cost()
+	})
+
+	// Rebuild the chain from left to right: ((a op b) op c) op d
+	left := operands[0]
+
+	for _, operand := range operands[1 : len(operands)-1] {
+		node := &Expression{Type: TypeLogical, Op: e.Op, Left: left, Right: operand}
+		left.Parent = node
+		operand.Parent = node
+		left = node
+	}
+
+	e.Left = left
+	e.Right = operands[len(operands)-1]
+	e.Left.Parent = e
+	e.Right.Parent = e
+}
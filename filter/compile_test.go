@@ -0,0 +1,99 @@
+package filter
+
+import (
+	"testing"
+)
+
+// testCompiledOrder checks that the filters are compiled to the same tree
+// as the expected filter expression
+func testCompiledOrder(t *testing.T, filterStrings []string, expected string) {
+	filter, err := Compile(filterStrings)
+
+	if err != nil {
+		t.Errorf("Filters %q couldn't be compiled: %s", filterStrings, err)
+		return
+	}
+
+	expectedFilter, _ := NewColumnFilter(expected)
+
+	if filter.String() != expectedFilter.String() {
+		t.Errorf("Compiled filter %s != expected %s", filter, expectedFilter)
+	}
+}
+
+func TestCompileMergesFilters(t *testing.T) {
+	filter, err := Compile([]string{"$1 == \"a\"", "$2 ~= \"^b\"", "$3 > 5"})
+
+	if err != nil {
+		t.Errorf("Filters couldn't be compiled: %s", err)
+		return
+	}
+
+	if !filter.Match(newExpectation(true, "a", "bc", "6").Line) {
+		t.Errorf("%s should match", filter)
+	}
+
+	for _, columns := range [][]string{{"x", "bc", "6"}, {"a", "cb", "6"}, {"a", "bc", "5"}} {
+		if filter.Match(newExpectation(false, columns...).Line) {
+			t.Errorf("%s should not match %q", filter, columns)
+		}
+	}
+}
+
+func TestCompileReturnsNilWithoutFilters(t *testing.T) {
+	filter, err := Compile([]string{})
+
+	if filter != nil || err != nil {
+		t.Errorf("Compile() should return nil for no filters, got %v, %v", filter, err)
+	}
+}
+
+func TestCompileReportsTheInvalidFilter(t *testing.T) {
+	_, err := Compile([]string{"$1 == \"a\"", "$2 === \"b\""})
+
+	syntaxErr, ok := err.(*SyntaxError)
+
+	if !ok {
+		t.Errorf("Compile() returned %T instead of *SyntaxError", err)
+		return
+	}
+
+	if syntaxErr.Filter != "$2 === \"b\"" {
+		t.Errorf("Syntax error is reported in '%s' instead of the second filter", syntaxErr.Filter)
+	}
+}
+
+func TestCompileOrdersCheapChecksFirst(t *testing.T) {
+	testCompiledOrder(t, []string{"$1 ~= \"^a\"", "$2 == \"b\""},
+		"$2 == \"b\" AND $1 ~= \"^a\"")
+
+	testCompiledOrder(t, []string{"$1 ~= $2 OR $3 > 5 OR $4 in (\"a\", \"b\")"},
+		"$4 in (\"a\", \"b\") OR $3 > 5 OR $1 ~= $2")
+
+	testCompiledOrder(t, []string{"NOT ($1 ~= \"^a\" AND $2 == \"b\")"},
+		"NOT ($2 == \"b\" AND $1 ~= \"^a\")")
+
+	testCompiledOrder(t, []string{"$1 ~= \"^a\"", "$2 == \"b\" AND $3 == \"c\"", "lower($4) == \"d\""},
+		"$2 == \"b\" AND $3 == \"c\" AND lower($4) == \"d\" AND $1 ~= \"^a\"")
+}
+
+func TestCompileKeepsPrecedence(t *testing.T) {
+	testCompiledOrder(t, []string{"$1 ~= \"a\" AND ($2 == \"b\" OR $3 == \"c\")"},
+		"($2 == \"b\" OR $3 == \"c\") AND $1 ~= \"a\"")
+}
+
+func BenchmarkCompiledFilters(b *testing.B) {
+	filter, _ := Compile([]string{"$5 ~= \"^CRON\\[[0-9]+\\]:$\"", "$7 == \"CMD\"", "$4 == \"otherserver\""})
+
+	for i := 0; i < b.N; i++ {
+		filter.Match(benchmarkLine)
+	}
+}
+
+func BenchmarkFiltersInGivenOrder(b *testing.B) {
+	filter, _ := NewColumnFilter("$5 ~= \"^CRON\\[[0-9]+\\]:$\" AND $7 == \"CMD\" AND $4 == \"otherserver\"")
+
+	for i := 0; i < b.N; i++ {
+		filter.Match(benchmarkLine)
+	}
+}
@@ -0,0 +1,109 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Function describes a built-in function that can be called from filter
+// expressions. Arguments are evaluated as strings, predicates return
+// "true" or "false" and can be used as conditions on their own.
+type Function struct {
+	MinArgs   int
+	MaxArgs   int
+	Predicate bool
+	Call      func(args []string) string
+}
+
+var functions = map[string]*Function{}
+
+// RegisterFunction makes a function available to filter expressions
+func RegisterFunction(name string, function *Function) {
+	functions[name] = function
+}
+
+func init() {
+	RegisterFunction("lower", &Function{MinArgs: 1, MaxArgs: 1, Call: lowerFunc})
+	RegisterFunction("upper", &Function{MinArgs: 1, MaxArgs: 1, Call: upperFunc})
+	RegisterFunction("len", &Function{MinArgs: 1, MaxArgs: 1, Call: lenFunc})
+	RegisterFunction("substr", &Function{MinArgs: 2, MaxArgs: 3, Call: substrFunc})
+	RegisterFunction("trim", &Function{MinArgs: 1, MaxArgs: 2, Call: trimFunc})
+	RegisterFunction("startswith", &Function{MinArgs: 2, MaxArgs: 2, Predicate: true, Call: startsWithFunc})
+	RegisterFunction("endswith", &Function{MinArgs: 2, MaxArgs: 2, Predicate: true, Call: endsWithFunc})
+	RegisterFunction("contains", &Function{MinArgs: 2, MaxArgs: 2, Predicate: true, Call: containsFunc})
+	RegisterFunction("isip", &Function{MinArgs: 1, MaxArgs: 1, Predicate: true, Call: isIPFunc})
+	RegisterFunction("isprivate", &Function{MinArgs: 1, MaxArgs: 1, Predicate: true, Call: isPrivateFunc})
+}
+
+func boolString(value bool) string {
+	if value {
+		return "true"
+	}
+
+	return "false"
+}
+
+func lowerFunc(args []string) string {
+	return strings.ToLower(args[0])
+}
+
+func upperFunc(args []string) string {
+	return strings.ToUpper(args[0])
+}
+
+func lenFunc(args []string) string {
+	return strconv.Itoa(utf8.RuneCountInString(args[0]))
+}
+
+// substrFunc returns the part of the string starting at the given
+// (zero-based) character index, optionally limited to the given length
+func substrFunc(args []string) string {
+	runes := []rune(args[0])
+
+	start, err := strconv.Atoi(strings.TrimSpace(args[1]))
+	if err != nil || start < 0 {
+		return ""
+	}
+
+	if start > len(runes) {
+		start = len(runes)
+	}
+
+	end := len(runes)
+
+	if len(args) > 2 {
+		length, err := strconv.Atoi(strings.TrimSpace(args[2]))
+		if err != nil || length < 0 {
+			return ""
+		}
+
+		if start+length < end {
+			end = start + length
+		}
+	}
+
+	return string(runes[start:end])
+}
+
+// trimFunc strips whitespace or the characters of the optional cutset from
+// both ends of the string
+func trimFunc(args []string) string {
+	if len(args) > 1 {
+		return strings.Trim(args[0], args[1])
+	}
+
+	return strings.TrimSpace(args[0])
+}
+
+func startsWithFunc(args []string) string {
+	return boolString(strings.HasPrefix(args[0], args[1]))
+}
+
+func endsWithFunc(args []string) string {
+	return boolString(strings.HasSuffix(args[0], args[1]))
+}
+
+func containsFunc(args []string) string {
+	return boolString(strings.Contains(args[0], args[1]))
+}
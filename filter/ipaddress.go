@@ -0,0 +1,78 @@
+package filter
+
+import (
+	"net"
+	"strings"
+)
+
+var privateNetworks = mustParseNetworks(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"fc00::/7",
+)
+
+func mustParseNetworks(cidrs ...string) []*net.IPNet {
+	networks := []*net.IPNet{}
+
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+// parseIP parses an IPv4 or IPv6 address, IPv6 addresses may be enclosed in
+// brackets. Returns nil if the value is not an address.
+func parseIP(value string) net.IP {
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		value = value[1 : len(value)-1]
+	}
+
+	return net.ParseIP(value)
+}
+
+// parseNetwork parses a network in CIDR notation, a single address is
+// treated as a network containing only that address
+func parseNetwork(value string) (*net.IPNet, error) {
+	value = strings.TrimSpace(value)
+
+	if !strings.Contains(value, "/") {
+		if ip := parseIP(value); ip != nil {
+			if ip4 := ip.To4(); ip4 != nil {
+				return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+			}
+
+			return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+		}
+	}
+
+	_, network, err := net.ParseCIDR(value)
+	return network, err
+}
+
+func isPrivateIP(ip net.IP) bool {
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isIPFunc(args []string) string {
+	return boolString(parseIP(args[0]) != nil)
+}
+
+func isPrivateFunc(args []string) string {
+	ip := parseIP(args[0])
+	return boolString(ip != nil && isPrivateIP(ip))
+}
@@ -0,0 +1,77 @@
+package filter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kbence/logan/utils"
+)
+
+// unit tells what kind of quantity a value is, only quantities of the same
+// unit can be compared to each other
+type unit uint8
+
+const (
+	unitNone unit = iota
+	unitTimeOfDay
+	unitDuration
+	unitSize
+)
+
+// parseQuantity interprets a string as a number, a time of day (HH:MM or
+// HH:MM:SS, represented as seconds since midnight), a duration like 523ms
+// (in seconds) or a size like 14KB (in bytes). NaN and infinite values
+// (which strconv.ParseFloat accepts) are not considered numbers.
+func parseQuantity(value string) (float64, unit, bool) {
+	value = strings.TrimSpace(value)
+
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return 0, unitNone, false
+		}
+
+		return number, unitNone, true
+	}
+
+	if seconds, ok := parseTimeOfDay(value); ok {
+		return seconds, unitTimeOfDay, true
+	}
+
+	if duration, err := utils.ParseDuration(value); err == nil {
+		return duration.Seconds(), unitDuration, true
+	}
+
+	if size, err := utils.ParseSize(value); err == nil {
+		return size, unitSize, true
+	}
+
+	return 0, unitNone, false
+}
+
+func parseTimeOfDay(value string) (float64, bool) {
+	parts := strings.Split(value, ":")
+
+	if len(parts) < 2 || len(parts) > 3 || len(parts[0]) > 2 || len(parts[1]) != 2 {
+		return 0, false
+	}
+
+	limits := []float64{24, 60, 60}
+	multipliers := []float64{3600, 60, 1}
+	seconds := 0.0
+
+	for i, part := range parts {
+		if part == "" || part[0] < '0' || part[0] > '9' {
+			return 0, false
+		}
+
+		component, err := strconv.ParseFloat(part, 64)
+		if err != nil || component >= limits[i] || (i < 2 && strings.Contains(part, ".")) {
+			return 0, false
+		}
+
+		seconds += component * multipliers[i]
+	}
+
+	return seconds, true
+}
@@ -0,0 +1,33 @@
+package filter
+
+import (
+	"strconv"
+	"time"
+)
+
+// evaluateTimeField returns the given component of a line's date, the
+// empty string if the line has no date
+func evaluateTimeField(field string, date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+
+	switch field {
+	case "time":
+		return date.Format("15:04:05")
+
+	case "date":
+		return date.Format("2006-01-02")
+
+	case "hour":
+		return strconv.Itoa(date.Hour())
+
+	case "minute":
+		return strconv.Itoa(date.Minute())
+
+	case "weekday":
+		return date.Format("Mon")
+	}
+
+	return ""
+}
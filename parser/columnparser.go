@@ -52,7 +52,66 @@ func getQuoteType(startChar byte) int {
 	return -1
 }
 
-func ParseColumns(output types.LogLineChannel, input types.LogLineChannel) {
+// splitColumns splits a line on whitespace, quoted and bracketed parts
+// are kept together
+func splitColumns(text string) types.ColumnList {
+	lineLen := len(text)
+	ws := true
+	quoted := false
+	quoteType := 0
+	columnBuffer := bytes.Buffer{}
+	currentColumn := 1
+	columns := types.ColumnList{}
+
+	for n := 0; n < lineLen; n++ {
+		char := text[n]
+		isws := isWhitespace(char)
+
+		if ws {
+			if !isws {
+				if !isQuoteStart(char) || separators[getQuoteType(char)].keep {
+					columnBuffer.WriteByte(char)
+				}
+				ws = false
+			}
+		} else {
+			if isws && !quoted {
+				columns[currentColumn] = columnBuffer.String()
+				currentColumn++
+				columnBuffer = bytes.Buffer{}
+				ws = true
+			} else {
+				if !isQuoteEnd(char) || separators[quoteType].keep {
+					columnBuffer.WriteByte(char)
+				}
+			}
+		}
+
+		if quoted && char == separators[quoteType].end || !quoted && isQuoteStart(char) {
+			quoted = !quoted
+
+			if quoted {
+				quoteType = getQuoteType(char)
+			}
+		}
+	}
+
+	if columnBuffer.Len() > 0 {
+		columns[currentColumn] = columnBuffer.String()
+	}
+
+	return columns
+}
+
+// ParseColumns splits the lines into columns according to the format,
+// header lines are dropped
+func ParseColumns(output types.LogLineChannel, input types.LogLineChannel, format Format) {
+	header, hasHeader := format.(headerFormat)
+	first := true
+
+	// The first line is held back until the next one tells if it's the header
+	var candidate *types.LogLine
+
 	for {
 		line, more := <-input
 
@@ -60,53 +119,39 @@ func ParseColumns(output types.LogLineChannel, input types.LogLineChannel) {
 			break
 		}
 
-		lineLen := len(line.Line)
-		ws := true
-		quoted := false
-		quoteType := 0
-		columnBuffer := bytes.Buffer{}
-		currentColumn := 1
-		line.Columns = map[int]string{}
-
-		for n := 0; n < lineLen; n++ {
-			char := line.Line[n]
-			isws := isWhitespace(char)
-
-			if ws {
-				if !isws {
-					if !isQuoteStart(char) || separators[getQuoteType(char)].keep {
-						columnBuffer.WriteByte(char)
-					}
-					ws = false
-				}
-			} else {
-				if isws && !quoted {
-					line.Columns[currentColumn] = columnBuffer.String()
-					currentColumn++
-					columnBuffer = bytes.Buffer{}
-					ws = true
-				} else {
-					if !isQuoteEnd(char) || separators[quoteType].keep {
-						columnBuffer.WriteByte(char)
-					}
-				}
+		format.ParseColumns(line)
+
+		if hasHeader {
+			if first && header.MayBeHeader(line) {
+				first = false
+				candidate = line
+				continue
 			}
 
-			if quoted && char == separators[quoteType].end || !quoted && isQuoteStart(char) {
-				quoted = !quoted
+			first = false
 
-				if quoted {
-					quoteType = getQuoteType(char)
+			if candidate != nil {
+				if header.DetectHeader(candidate, line) {
+					// Parse again to name the columns after the header
+					format.ParseColumns(line)
+				} else {
+					output <- candidate
 				}
+
+				candidate = nil
 			}
-		}
 
-		if columnBuffer.Len() > 0 {
-			line.Columns[currentColumn] = columnBuffer.String()
+			if header.IsHeader(line) {
+				continue
+			}
 		}
 
 		output <- line
 	}
 
+	if candidate != nil {
+		output <- candidate
+	}
+
 	close(output)
 }
@@ -29,7 +29,7 @@ func TestSimpleColumns(t *testing.T) {
 	input := makeChan("first second third")
 	output := make(types.LogLineChannel)
 
-	go ParseColumns(output, input)
+	go ParseColumns(output, input, &GenericFormat{})
 
 	result := <-output
 
@@ -48,7 +48,7 @@ func TestQuotedColumns(t *testing.T) {
 	input := makeChan("first \"second column\" 'third'")
 	output := make(types.LogLineChannel)
 
-	go ParseColumns(output, input)
+	go ParseColumns(output, input, &GenericFormat{})
 
 	result := <-output
 
@@ -65,7 +65,7 @@ func TestBracketedColumns(t *testing.T) {
 	input := makeChan("first [second column] (third column)")
 	output := make(types.LogLineChannel)
 
-	go ParseColumns(output, input)
+	go ParseColumns(output, input, &GenericFormat{})
 
 	result := <-output
 
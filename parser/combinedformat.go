@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+const combinedTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// combinedLineMatcher matches lines in the common log format, optionally
+// followed by the referer and user agent of the combined log format
+var combinedLineMatcher = regexp.MustCompile(
+	`^(\S+) (\S+) (\S+) \[([^\]]+)\] "((?:[^"\\]|\\.)*)" (\S+) (\S+)` +
+		`(?: "((?:[^"\\]|\\.)*)" "((?:[^"\\]|\\.)*)")?`)
+
+var combinedFieldNames = []string{"remote_addr", "ident", "user", "time", "method", "path",
+	"protocol", "status", "bytes", "referer", "user_agent"}
+
+func init() {
+	formatFactories["combined"] = func(options FormatOptions) (Format, error) {
+		return NewCombinedFormat(), nil
+	}
+	formatFactories["common"] = formatFactories["combined"]
+}
+
+// CombinedFormat parses access logs in the common or combined log format
+// used by Apache and nginx, e.g.
+//
+//	127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /a.gif HTTP/1.0" 200 2326 "-" "curl/7.0"
+//
+// The request is split into method, path and protocol. Lines in other
+// formats are parsed as generic lines.
+type CombinedFormat struct {
+	names types.ColumnNames
+}
+
+// NewCombinedFormat creates a parser for the common and combined log format
+func NewCombinedFormat() *CombinedFormat {
+	names := types.ColumnNames{}
+
+	for i, name := range combinedFieldNames {
+		names[name] = i + 1
+	}
+
+	return &CombinedFormat{names: names}
+}
+
+func (f *CombinedFormat) ParseColumns(line *types.LogLine) {
+	match := combinedLineMatcher.FindStringSubmatchIndex(line.Line)
+
+	if match == nil {
+		line.Columns = splitColumns(line.Line)
+		return
+	}
+
+	group := func(n int) (string, bool) {
+		if match[2*n] < 0 {
+			return "", false
+		}
+
+		return line.Line[match[2*n]:match[2*n+1]], true
+	}
+
+	columns := types.ColumnList{}
+
+	for n := 1; n <= 4; n++ {
+		columns[n], _ = group(n)
+	}
+
+	// The parts of the request keep their columns even if it's malformed
+	// (e.g. "-"), so that the following columns are numbered the same way
+	request, _ := group(5)
+	parts := strings.SplitN(request, " ", 3)
+
+	for i := 0; i < 3; i++ {
+		columns[5+i] = ""
+
+		if i < len(parts) {
+			columns[5+i] = parts[i]
+		}
+	}
+
+	columns[8], _ = group(6)
+	columns[9], _ = group(7)
+
+	if referer, found := group(8); found {
+		columns[10] = referer
+		columns[11], _ = group(9)
+	}
+
+	line.Columns = columns
+	line.Names = f.names
+}
+
+func (f *CombinedFormat) ParseDate(line *types.LogLine) *time.Time {
+	value, found := line.Field("time")
+
+	if !found {
+		return nil
+	}
+
+	date, err := time.Parse(combinedTimeLayout, value)
+
+	if err != nil {
+		return nil
+	}
+
+	return &date
+}
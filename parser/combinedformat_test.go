@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+func parseCombinedLine(text string) *types.LogLine {
+	line := &types.LogLine{Line: text}
+	NewCombinedFormat().ParseColumns(line)
+	return line
+}
+
+func TestCombinedLogFormatIsParsed(t *testing.T) {
+	line := parseCombinedLine(`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" ` +
+		`200 2326 "http://www.example.com/start.html" "Mozilla/4.08 [en] (Win98; I ;Nav)"`)
+
+	expectField(t, line, "remote_addr", "127.0.0.1")
+	expectField(t, line, "user", "frank")
+	expectField(t, line, "time", "10/Oct/2000:13:55:36 -0700")
+	expectField(t, line, "method", "GET")
+	expectField(t, line, "path", "/apache_pb.gif")
+	expectField(t, line, "protocol", "HTTP/1.0")
+	expectField(t, line, "status", "200")
+	expectField(t, line, "bytes", "2326")
+	expectField(t, line, "referer", "http://www.example.com/start.html")
+	expectField(t, line, "user_agent", "Mozilla/4.08 [en] (Win98; I ;Nav)")
+}
+
+func TestCommonLogFormatIsParsed(t *testing.T) {
+	line := parseCombinedLine(`::1 - - [10/Oct/2000:13:55:36 +0000] "GET /a\"b HTTP/1.1" 304 -`)
+
+	expectField(t, line, "remote_addr", "::1")
+	expectField(t, line, "path", `/a\"b`)
+	expectField(t, line, "status", "304")
+	expectField(t, line, "bytes", "-")
+
+	if _, found := line.Field("user_agent"); found {
+		t.Errorf("Lines in common log format shouldn't have a user agent")
+	}
+}
+
+func TestMalformedRequestIsKept(t *testing.T) {
+	line := parseCombinedLine(`10.0.0.1 - - [10/Oct/2000:13:55:36 +0000] "-" 400 0 "-" "-"`)
+
+	expectField(t, line, "method", "-")
+	expectField(t, line, "path", "")
+	expectField(t, line, "protocol", "")
+	expectField(t, line, "status", "400")
+
+	// Columns after the request are numbered the same way as in other lines
+	if line.Columns[8] != "400" || line.Columns[10] != "-" || line.Columns[11] != "-" {
+		t.Errorf("Columns after a malformed request are misnumbered: %v", line.Columns)
+	}
+}
+
+func TestCombinedDateIsParsed(t *testing.T) {
+	line := parseCombinedLine(`127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0" 200 1`)
+	expected := time.Date(2000, 10, 10, 20, 55, 36, 0, time.UTC)
+
+	if date := NewCombinedFormat().ParseDate(line); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+
+	line = parseCombinedLine("not an access log line")
+
+	if date := NewCombinedFormat().ParseDate(line); date != nil {
+		t.Errorf("Line in other format returned date %v", date)
+	}
+}
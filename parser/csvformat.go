@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"encoding/csv"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+// headerFieldMatcher matches field values that look like column names
+var headerFieldMatcher = regexp.MustCompile(`^[A-Za-z_@][A-Za-z0-9_@ ./()-]*$`)
+
+func init() {
+	formatFactories["csv"] = func(options FormatOptions) (Format, error) {
+		return NewCSVFormat(',', options.Fields, options.TimeKey, options.Dates), nil
+	}
+	formatFactories["tsv"] = func(options FormatOptions) (Format, error) {
+		return NewCSVFormat('\t', options.Fields, options.TimeKey, options.Dates), nil
+	}
+}
+
+// CSVFormat parses comma (or tab) separated values, quoted as described in
+// RFC 4180. Columns are named after the configured fields or, if there are
+// none, after the header: the first line of the log if its fields all look
+// like names and the line after it has values that don't. Header lines
+// (repeated at the beginning of every file) are left out. Quoted values
+// spanning multiple lines are not supported.
+type CSVFormat struct {
+	separator rune
+	timeKeys  []string
+	dates     *DateParser
+
+	mutex      sync.Mutex
+	headerLine string
+	names      types.ColumnNames
+}
+
+// NewCSVFormat creates a CSV format with the given separator, the date is
+// taken from the timeKey column, which can be a name or a column number. If
+// there's no such column, the first value that looks like a date is used.
+func NewCSVFormat(separator rune, fields []string, timeKey string, dates *DateParser) *CSVFormat {
+	format := &CSVFormat{separator: separator, timeKeys: timeKeys(timeKey), dates: dates}
+
+	if len(fields) > 0 {
+		format.names = namesOf(fields)
+	}
+
+	return format
+}
+
+func (f *CSVFormat) splitFields(text string) ([]string, error) {
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = f.separator
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	return reader.Read()
+}
+
+func (f *CSVFormat) getNames() types.ColumnNames {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.names
+}
+
+// looksLikeHeader tells if all the values of the line look like names
+func looksLikeHeader(line *types.LogLine) bool {
+	for _, value := range line.Columns {
+		if !headerFieldMatcher.MatchString(strings.TrimSpace(value)) {
+			return false
+		}
+	}
+
+	return len(line.Columns) > 0
+}
+
+// MayBeHeader tells if the first line of the log may be its header, which
+// is decided by DetectHeader once the next line is read
+func (f *CSVFormat) MayBeHeader(line *types.LogLine) bool {
+	return f.getNames() == nil && looksLikeHeader(line)
+}
+
+// DetectHeader takes first as the header if the line after it differs in
+// shape, i.e. it has values that don't look like names. A log without a
+// header (or read from past its header) whose lines are all words, like
+// INFO,alice,login, is left unnamed this way.
+func (f *CSVFormat) DetectHeader(first, next *types.LogLine) bool {
+	if looksLikeHeader(next) {
+		return false
+	}
+
+	fields := make([]string, len(first.Columns))
+
+	for column, value := range first.Columns {
+		fields[column-1] = value
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.headerLine = first.Line
+	f.names = namesOf(fields)
+
+	return true
+}
+
+func (f *CSVFormat) ParseColumns(line *types.LogLine) {
+	fields, err := f.splitFields(line.Line)
+
+	if err != nil {
+		line.Columns = splitColumns(line.Line)
+		return
+	}
+
+	columns := types.ColumnList{}
+
+	for i, field := range fields {
+		columns[i+1] = field
+	}
+
+	line.Columns = columns
+	line.Names = f.getNames()
+}
+
+// IsHeader tells if the line is the header of the file
+func (f *CSVFormat) IsHeader(line *types.LogLine) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.headerLine != "" && line.Line == f.headerLine
+}
+
+func (f *CSVFormat) ParseDate(line *types.LogLine) *time.Time {
+	for _, key := range f.timeKeys {
+		if column, err := strconv.Atoi(key); err == nil {
+			if value, found := line.Columns[column]; found {
+				return f.dates.ParseValue(value)
+			}
+		}
+
+		if value, found := line.Field(key); found {
+			return f.dates.ParseValue(value)
+		}
+	}
+
+	// Without a time column (e.g. before the header has been seen) the first
+	// value that looks like a date is used. Numbers are not taken as Unix
+	// timestamps here, as IDs and counters would be taken for dates too.
+	for _, column := range line.Columns.SortedKeys() {
+		if date := f.dates.parseValue(line.Columns[column], false); date != nil {
+			return date
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+func parseCSVLine(format *CSVFormat, text string) *types.LogLine {
+	line := &types.LogLine{Line: text}
+	format.ParseColumns(line)
+	return line
+}
+
+func TestCSVQuotingIsHonoured(t *testing.T) {
+	format := NewCSVFormat(',', []string{"user", "action", "comment"}, "", nil)
+	line := parseCSVLine(format, `alice,"login, then logout","said ""hi"""`)
+
+	expectField(t, line, "user", "alice")
+	expectField(t, line, "action", "login, then logout")
+	expectField(t, line, "comment", `said "hi"`)
+}
+
+// parseCSVLog runs the lines through the column parser, which leaves out
+// the header lines
+func parseCSVLog(format *CSVFormat, texts ...string) []*types.LogLine {
+	input := make(types.LogLineChannel)
+	output := make(types.LogLineChannel)
+
+	go func() {
+		for _, text := range texts {
+			input <- &types.LogLine{Line: text}
+		}
+		close(input)
+	}()
+
+	go ParseColumns(output, input, format)
+
+	lines := []*types.LogLine{}
+	for line := range output {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func TestCSVColumnNamesAreTakenFromTheHeader(t *testing.T) {
+	format := NewCSVFormat(',', nil, "", nil)
+	lines := parseCSVLog(format,
+		"logged_at,user,action",
+		"2017-02-26T08:00:05Z,bob,delete",
+		"logged_at,user,action",
+		"2017-02-26T08:00:06Z,alice,create")
+
+	if len(lines) != 2 {
+		t.Fatalf("Header lines should be left out, got %d lines", len(lines))
+	}
+
+	expectField(t, lines[0], "user", "bob")
+	expectField(t, lines[0], "action", "delete")
+	expectField(t, lines[1], "user", "alice")
+}
+
+func TestCSVHeaderIsNotDetectedFromData(t *testing.T) {
+	format := NewCSVFormat(',', nil, "", nil)
+	lines := parseCSVLog(format,
+		"2017-02-26T08:00:05Z,bob,42",
+		"time,user,count",
+		"2017-02-26T08:00:06Z,bob,43")
+
+	if len(lines) != 3 {
+		t.Fatalf("No line should be left out, got %d lines", len(lines))
+	}
+
+	for _, line := range lines {
+		if len(line.Names) != 0 {
+			t.Errorf("Only the first line may be the header, got names %v", line.Names)
+		}
+	}
+}
+
+func TestCSVWithoutHeaderIsLeftUnnamed(t *testing.T) {
+	format := NewCSVFormat(',', nil, "", nil)
+	lines := parseCSVLog(format, "INFO,alice,login", "WARN,bob,logout", "INFO,carol,login")
+
+	if len(lines) != 3 {
+		t.Fatalf("No line should be left out, got %d lines", len(lines))
+	}
+
+	for _, line := range lines {
+		if len(line.Names) != 0 {
+			t.Errorf("Data line '%s' shouldn't be taken as the header", line.Line)
+		}
+	}
+
+	if lines[0].Columns[2] != "alice" {
+		t.Errorf("Column 2 (%s) != expected (alice)", lines[0].Columns[2])
+	}
+
+	if lines := parseCSVLog(NewCSVFormat(',', nil, "", nil), "INFO,alice,login"); len(lines) != 1 {
+		t.Errorf("Single line shouldn't be left out")
+	}
+}
+
+func TestTSVIsParsed(t *testing.T) {
+	format := NewCSVFormat('\t', []string{"level", "msg"}, "", nil)
+	line := parseCSVLine(format, "INFO\tcomma, separated\t")
+
+	expectField(t, line, "level", "INFO")
+	expectField(t, line, "msg", "comma, separated")
+
+	if len(line.Columns) != 3 {
+		t.Errorf("Empty trailing field should be kept, got %v", line.Columns)
+	}
+}
+
+func TestCSVDateIsTakenFromTheNominatedColumn(t *testing.T) {
+	expected := time.Date(2017, 2, 26, 8, 0, 5, 0, time.UTC)
+
+	format := NewCSVFormat(',', []string{"created", "logged_at"}, "logged_at", nil)
+	line := parseCSVLine(format, "2016-01-01T00:00:00Z,2017-02-26T08:00:05Z")
+	if date := format.ParseDate(line); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+
+	format = NewCSVFormat(',', nil, "2", nil)
+	line = parseCSVLine(format, "2016-01-01T00:00:00Z,2017-02-26T08:00:05Z")
+	if date := format.ParseDate(line); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+
+	format = NewCSVFormat(',', nil, "", nil)
+	line = parseCSVLine(format, "bob,2017-02-26T08:00:05Z")
+	if date := format.ParseDate(line); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+}
+
+func TestCSVNumbersAreNotTakenForDatesWithoutTimeKey(t *testing.T) {
+	expected := time.Date(2017, 2, 26, 8, 0, 5, 0, time.UTC)
+
+	format := NewCSVFormat(',', nil, "", nil)
+	line := parseCSVLine(format, "1508323200123,bob,2017-02-26T08:00:05Z")
+	if date := format.ParseDate(line); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+
+	line = parseCSVLine(format, "1508323200123,bob,42")
+	if date := format.ParseDate(line); date != nil {
+		t.Errorf("Line '%s' shouldn't have a date, got '%s'!", line.Line, *date)
+	}
+
+	// Named time columns may still hold Unix timestamps
+	format = NewCSVFormat(',', []string{"id", "logged_at"}, "logged_at", nil)
+	line = parseCSVLine(format, "1,1488096005")
+	if date := format.ParseDate(line); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+}
@@ -3,11 +3,17 @@ package parser
 import (
 	"regexp"
 	"strings"
+	"time"
 )
 
 type preprocessorFunc func(string) string
 
+// dateParser parses one of the built-in date formats, either with a
+// hand-written scanner or by matching the date with a regular expression
+// and parsing it with a layout. The formats are mutually exclusive, a line
+// can only match one of them.
 type dateParser struct {
+	scan         scanFunc
 	reg          *regexp.Regexp
 	layout       string
 	preprocessor preprocessorFunc
@@ -21,26 +27,9 @@ var dateParsers = []dateParser{
 		reg:          regexp.MustCompile("^(\\d{4}-\\d{2}-\\d{2}T\\d{2}:\\d{2}:\\d{2}[.,]\\d{6}[+-] ([+-]\\d{4}|[A-Z]{3}))"),
 		layout:       "2006-01-02T15:04:05.000000 -0700",
 		preprocessor: replaceCommaToPoint},
-	dateParser{
-		reg:          regexp.MustCompile("^(\\d{4}-\\d{2}-\\d{2}T\\d{2}:\\d{2}:\\d{2}[.,]\\d{3}[+-]\\d{4})"),
-		layout:       "2006-01-02T15:04:05.000-0700",
-		preprocessor: replaceCommaToPoint},
-	dateParser{
-		reg:          regexp.MustCompile("^(\\d{4}-\\d{2}-\\d{2} \\d{2}:\\d{2}:\\d{2}[.,]\\d{3})"),
-		layout:       "2006-01-02 15:04:05.000",
-		preprocessor: replaceCommaToPoint},
-	dateParser{
-		reg:          regexp.MustCompile("^(\\d{4}-\\d{2}-\\d{2} \\d{2}:\\d{2}:\\d{2})"),
-		layout:       "2006-01-02 15:04:05",
-		preprocessor: noop},
-	dateParser{
-		reg:          regexp.MustCompile("^(\\w{3} ( ?\\d|\\d{2}) \\d{2}:\\d{2}:\\d{2} \\d{4})"),
-		layout:       "Jan 2 15:04:05 2006",
-		preprocessor: normalizeSpaces},
-	dateParser{
-		reg:          regexp.MustCompile("^(\\w{3} ( ?\\d|\\d{2}) \\d{2}:\\d{2}:\\d{2})"),
-		layout:       "Jan 2 15:04:05",
-		preprocessor: normalizeSpaces},
+	dateParser{scan: scanISODateWithZone},
+	dateParser{scan: scanISODate},
+	dateParser{scan: scanSyslogDate},
 	dateParser{
 		reg:          regexp.MustCompile("^(\\w{3},? \\d{2} \\w{3} \\d{2}:\\d{2}:\\d{2}.\\d{3} [+-]\\d{4})"),
 		layout:       "Mon 02 Jan 15:04:05.000 -0700",
@@ -60,9 +49,31 @@ var dateParsers = []dateParser{
 	dateParser{
 		reg:          regexp.MustCompile("^(\\w{3} \\w{3} ( \\d|\\d{2}) \\d{2}:\\d{2}:\\d{2}.\\d{3})"),
 		layout:       "Mon Jan 2 15:04:05.000",
-		preprocessor: normalizeSpaces}}
+		preprocessor: normalizeSpaces},
+	dateParser{scan: scanAccessLogDate}}
+
+// parse returns the date at the beginning of the text, nil if it's not in
+// the parser's format
+func (p *dateParser) parse(text string) *time.Time {
+	if p.scan != nil {
+		return p.scan(text)
+	}
+
+	date := p.reg.FindString(text)
 
-func noop(input string) string { return input }
+	if date == "" {
+		return nil
+	}
+
+	parsedDate, err := time.ParseInLocation(p.layout, p.preprocessor(date), getLocation())
+
+	if err != nil {
+		return nil
+	}
+
+	parsedDate = completeYear(parsedDate)
+	return &parsedDate
+}
 
 func replaceCommaToPoint(input string) string {
 	return strings.Replace(input, ",", ".", -1)
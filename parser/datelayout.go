@@ -0,0 +1,252 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+// dateLayout is a user-defined date format: a layout (see time.Parse),
+// optionally with a regular expression or a column number telling where the
+// date is in the line. Without them the date is expected at the beginning
+// of the line.
+type dateLayout struct {
+	layout string
+	words  int
+	reg    *regexp.Regexp
+	column int
+}
+
+// parseDateLayout parses a date format specification, which is a layout
+// optionally followed by a '|' and a column number or a regular expression.
+// The first group of the expression (or the whole match if it has none) is
+// parsed as the date.
+func parseDateLayout(spec string) (*dateLayout, error) {
+	parts := strings.SplitN(spec, "|", 2)
+	layout := &dateLayout{layout: normalizeSpaces(strings.TrimSpace(parts[0]))}
+	layout.words = len(strings.Fields(layout.layout))
+
+	if layout.words == 0 {
+		return nil, fmt.Errorf("date format '%s' has no layout", spec)
+	}
+
+	if len(parts) == 1 || parts[1] == "" {
+		return layout, nil
+	}
+
+	if column, err := strconv.Atoi(parts[1]); err == nil {
+		if column < 1 {
+			return nil, fmt.Errorf("invalid field number in date format '%s'", spec)
+		}
+
+		layout.column = column
+		return layout, nil
+	}
+
+	reg, err := regexp.Compile(parts[1])
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression in date format '%s': %s", spec, err)
+	}
+
+	layout.reg = reg
+	return layout, nil
+}
+
+// extract returns the part of the text that should contain the date
+func (l *dateLayout) extract(text string) string {
+	if l.reg != nil {
+		match := l.reg.FindStringSubmatch(text)
+
+		switch {
+		case match == nil:
+			return ""
+		case len(match) > 1:
+			return match[1]
+		}
+
+		return match[0]
+	}
+
+	// Take as many words from the beginning as there are in the layout
+	words := strings.Fields(text)
+
+	if len(words) > l.words {
+		words = words[:l.words]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// fieldText returns the date from the columns starting at the field the
+// layout is bound to. The date may be split into several columns or be a
+// part of one (e.g. when it's in brackets), the brackets and quotes around
+// it are left out.
+func (l *dateLayout) fieldText(line *types.LogLine) string {
+	return strings.TrimRight(l.extract(columnText(line, l.column, l.words)), "])>}\"'")
+}
+
+func (l *dateLayout) parse(text string) *time.Time {
+	if text == "" {
+		return nil
+	}
+
+	date, err := time.ParseInLocation(l.layout, normalizeSpaces(strings.TrimSpace(text)), getLocation())
+
+	if err != nil {
+		return nil
+	}
+
+	date = completeYear(date)
+	return &date
+}
+
+// maxDateColumns is the number of columns a date may span, e.g. 3 for
+// "Dec  5 06:57:36"
+const maxDateColumns = 6
+
+// DateOptions holds the settings of a date parser
+type DateOptions struct {
+	// Formats are the user-defined date formats, see parseDateLayout for
+	// their syntax
+	Formats []string
+	// EpochUnit is the unit of Unix timestamps (s, ms, us or ns), it's
+	// guessed from their magnitude if empty
+	EpochUnit string
+	// Column is the column the date starts in, 0 if it's looked for in the
+	// whole line
+	Column int
+	// Search tells if dates are looked for inside the lines that don't start
+	// with one. It's off by default, as lines like the ones of stack traces
+	// would get the dates they mention.
+	Search bool
+}
+
+// DateParser parses dates trying the user-defined date formats first, then
+// the built-in ones. The nil DateParser uses the built-in formats only.
+type DateParser struct {
+	layouts   []*dateLayout
+	epochUnit time.Duration
+	column    int
+	search    bool
+	// hint is the index of the built-in format that matched last, it's
+	// shared by the goroutines parsing the same log
+	hint int32
+}
+
+// NewDateParser creates a date parser with the given options
+func NewDateParser(options DateOptions) (*DateParser, error) {
+	unit, err := ParseEpochUnit(options.EpochUnit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Column < 0 {
+		return nil, fmt.Errorf("invalid date column %d", options.Column)
+	}
+
+	parser := &DateParser{epochUnit: unit, column: options.Column, search: options.Search}
+
+	for _, spec := range options.Formats {
+		layout, err := parseDateLayout(spec)
+
+		if err != nil {
+			return nil, err
+		}
+
+		parser.layouts = append(parser.layouts, layout)
+	}
+
+	return parser, nil
+}
+
+// ParseLine returns the date of a line that has already been split into
+// columns. With a date column set, the built-in formats are tried at the
+// beginning of that column before the beginning of the line. The rest of
+// the line is only searched if Search was set.
+func (p *DateParser) ParseLine(line *types.LogLine) *time.Time {
+	if p == nil {
+		return ParseDate(line.Line)
+	}
+
+	for _, layout := range p.layouts {
+		text := ""
+
+		if layout.column > 0 {
+			text = layout.fieldText(line)
+		} else {
+			text = layout.extract(line.Line)
+		}
+
+		if date := layout.parse(text); date != nil {
+			return date
+		}
+	}
+
+	if p.column > 0 {
+		if date := parseDateAtStart(columnText(line, p.column, maxDateColumns), p.epochUnit, &p.hint); date != nil {
+			return date
+		}
+	}
+
+	if p.search {
+		return parseDate(line.Line, p.epochUnit, &p.hint)
+	}
+
+	return parseDateAtStart(line.Line, p.epochUnit, &p.hint)
+}
+
+// columnText joins at most count columns starting at the given one, as a
+// date may span several of them. Opening brackets and quotes before the date
+// are left out.
+func columnText(line *types.LogLine, first, count int) string {
+	words := []string{}
+
+	for column := first; column < first+count; column++ {
+		word, found := line.Columns[column]
+
+		if !found {
+			break
+		}
+
+		words = append(words, word)
+	}
+
+	return strings.TrimLeft(strings.Join(words, " "), "[(<{\"'")
+}
+
+// ParseValue parses a date stored in a field of its own, formats bound to
+// columns are not used here
+func (p *DateParser) ParseValue(value string) *time.Time {
+	return p.parseValue(value, true)
+}
+
+// parseValue parses a date stored in a field of its own, numbers are taken
+// as Unix timestamps only if epochs is set
+func (p *DateParser) parseValue(value string, epochs bool) *time.Time {
+	if p == nil {
+		return parseDateValue(value, 0, nil, epochs)
+	}
+
+	for _, layout := range p.layouts {
+		if layout.column > 0 {
+			continue
+		}
+
+		text := value
+		if layout.reg != nil {
+			text = layout.extract(value)
+		}
+
+		if date := layout.parse(text); date != nil {
+			return date
+		}
+	}
+
+	return parseDateValue(value, p.epochUnit, &p.hint, epochs)
+}
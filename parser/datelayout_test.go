@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+func expectLineDate(t *testing.T, dates *DateParser, line *types.LogLine, expected time.Time) {
+	date := dates.ParseLine(line)
+
+	if date == nil {
+		t.Errorf("Date from line '%s' couldn't be parsed!", line.Line)
+		return
+	}
+
+	if !date.Equal(expected) {
+		t.Errorf("Date from line '%s' (parsed as '%s') doesn't match expected '%s'!", line.Line, *date, expected)
+	}
+}
+
+func TestDateParserParsesDateAtBeginningOfLine(t *testing.T) {
+	dates, err := NewDateParser(DateOptions{Formats: []string{"2006/01/02 15h04m05s -0700"}})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := time.Date(2016, 12, 5, 6, 57, 36, 0, time.UTC)
+	expectLineDate(t, dates, logLine("2016/12/05  07h57m36s +0100 worker started"), expected)
+}
+
+func TestDateParserExtractsDateWithRegex(t *testing.T) {
+	dates, _ := NewDateParser(DateOptions{Formats: []string{`02.01.2006 15:04:05 MST|at \[([^\]]+)\]`}})
+
+	expected := time.Date(2016, 12, 5, 6, 57, 36, 0, time.UTC)
+	expectLineDate(t, dates, logLine("worker started at [05.12.2016 06:57:36 UTC]"), expected)
+
+	if date := dates.ParseValue("at [05.12.2016 06:57:36 UTC]"); date == nil || !date.Equal(expected) {
+		t.Errorf("Date value was parsed as %v instead of '%s'!", date, expected)
+	}
+}
+
+func TestDateParserTakesDateFromField(t *testing.T) {
+	dates, _ := NewDateParser(DateOptions{Formats: []string{"2006-01-02T15:04:05Z07:00|3"}})
+	line := logLine("worker-1 started 2016-12-05T06:57:36Z")
+	(&GenericFormat{}).ParseColumns(line)
+
+	expectLineDate(t, dates, line, time.Date(2016, 12, 5, 6, 57, 36, 0, time.UTC))
+}
+
+func TestDateParserTakesDateSpanningFieldsFromField(t *testing.T) {
+	for spec, text := range map[string]string{
+		"02.01.2006 15:04|2":    "INFO 03.01.2020 10:00 hello",
+		"2006-01-02 15:04:05|1": "[2020-01-03 10:00:00] hello",
+		"2006-01-02 15:04:05|2": `INFO "2020-01-03 10:00:00" hello`,
+		"02.01.2006T15:04:05|2": "INFO (03.01.2020T10:00:00)",
+	} {
+		dates, err := NewDateParser(DateOptions{Formats: []string{spec}})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		line := logLine(text)
+		(&GenericFormat{}).ParseColumns(line)
+
+		expectLineDate(t, dates, line, time.Date(2020, 1, 3, 10, 0, 0, 0, getLocation()))
+	}
+}
+
+func TestDateParserFallsBackToBuiltInFormats(t *testing.T) {
+	dates, _ := NewDateParser(DateOptions{Formats: []string{"2006/01/02 15h04m05s -0700"}})
+	line := logLine("2016-12-05 06:57:36 worker started")
+
+	if date := dates.ParseLine(line); date == nil || *date != *ParseDate(line.Line) {
+		t.Errorf("Built-in date format wasn't used for '%s'!", line.Line)
+	}
+
+	if date := (*DateParser)(nil).ParseLine(line); date == nil {
+		t.Errorf("Built-in date format wasn't used by the nil parser for '%s'!", line.Line)
+	}
+}
+
+func TestDateParserReportsInvalidFormats(t *testing.T) {
+	for _, spec := range []string{"", "|1", "2006-01-02|0", "2006-01-02|(unclosed"} {
+		if _, err := NewDateParser(DateOptions{Formats: []string{spec}}); err == nil {
+			t.Errorf("Date format '%s' should be invalid!", spec)
+		}
+	}
+}
+
+func TestDateParserLooksForDateInColumn(t *testing.T) {
+	dates, _ := NewDateParser(DateOptions{Column: 4})
+	line := logLine(`1.2.3.4 2001-01-01 00:00:00 [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.1"`)
+	(&GenericFormat{}).ParseColumns(line)
+
+	expectLineDate(t, dates, line, time.Date(2000, 10, 10, 13, 55, 36, 0, time.FixedZone("", -7*3600)))
+
+	if _, err := NewDateParser(DateOptions{Column: -1}); err == nil {
+		t.Errorf("Date column -1 should be invalid!")
+	}
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"log"
+	"sync/atomic"
 	"time"
 
 	"github.com/kbence/logan/types"
@@ -24,37 +25,151 @@ func getLocation() *time.Location {
 	return location
 }
 
+// SetLocation sets the time zone of the dates that don't specify their own
+func SetLocation(loc *time.Location) {
+	location = loc
+}
+
+// completeYear sets the year of dates parsed from layouts without one, it's
+// the current year unless the date would be in the future
+func completeYear(date time.Time) time.Time {
+	if date.Year() != 0 {
+		return date
+	}
+
+	now := time.Now().In(date.Location())
+	date = time.Date(now.Year(), date.Month(), date.Day(), date.Hour(), date.Minute(),
+		date.Second(), date.Nanosecond(), date.Location())
+
+	if date.After(now) {
+		date = time.Date(now.Year()-1, date.Month(), date.Day(), date.Hour(), date.Minute(),
+			date.Second(), date.Nanosecond(), date.Location())
+	}
+
+	return date
+}
+
+// maxDateOffset limits how far dates are looked for in lines that don't
+// start with one
+const maxDateOffset = 256
+
+// ParseDate parses the date at the beginning of the line in any of the
+// built-in formats, Unix timestamps included
 func ParseDate(line string) *time.Time {
-	for _, parser := range dateParsers {
-		date := parser.reg.FindString(line)
+	return parseDateAtStart(line, 0, nil)
+}
 
-		if date != "" {
-			parsedDate, err := time.ParseInLocation(parser.layout, parser.preprocessor(date), getLocation())
+// parseDate parses the date of the line, looking for it anywhere in its
+// first maxDateOffset bytes if it doesn't start with one. Unix timestamps
+// are taken in epochUnit or in the unit guessed from their magnitude if
+// it's 0. See parseLayoutDate for hint.
+func parseDate(line string, epochUnit time.Duration, hint *int32) *time.Time {
+	if date := parseDateAtStart(line, epochUnit, hint); date != nil {
+		return date
+	}
 
-			if err != nil {
-				continue
-			}
+	return searchDate(line, hint)
+}
+
+// parseDateAtStart parses the date (or Unix timestamp) at the beginning of
+// the text
+func parseDateAtStart(line string, epochUnit time.Duration, hint *int32) *time.Time {
+	if date := parseLayoutDate(line, hint); date != nil {
+		return date
+	}
+
+	return parseEpoch(line, epochUnit)
+}
+
+// parseLayoutDate parses the date at the beginning of the text in any of
+// the built-in date formats. If hint is not nil, the format that matched
+// last time is tried first and hint is updated when another one matches.
+// As the formats are mutually exclusive, the order doesn't change the
+// result. Lines of a log are usually in the same format, so this saves
+// trying all the others.
+func parseLayoutDate(line string, hint *int32) *time.Time {
+	first := -1
+
+	if hint != nil {
+		first = int(atomic.LoadInt32(hint))
+
+		if date := dateParsers[first].parse(line); date != nil {
+			return date
+		}
+	}
 
-			if parsedDate.Year() == 0 {
-				now := time.Now()
-				parsedDate = time.Date(now.Year(), parsedDate.Month(), parsedDate.Day(),
-					parsedDate.Hour(), parsedDate.Minute(), parsedDate.Second(),
-					parsedDate.Nanosecond(), parsedDate.Location())
-
-				if parsedDate.After(now) {
-					parsedDate = time.Date(now.Year()-1, parsedDate.Month(), parsedDate.Day(),
-						parsedDate.Hour(), parsedDate.Minute(), parsedDate.Second(),
-						parsedDate.Nanosecond(), parsedDate.Location())
-				}
+	for i := range dateParsers {
+		if i == first {
+			continue
+		}
+
+		if date := dateParsers[i].parse(line); date != nil {
+			if hint != nil {
+				atomic.StoreInt32(hint, int32(i))
 			}
-			return &parsedDate
+
+			return date
 		}
 	}
 
 	return nil
 }
 
-func ParseDates(output types.LogLineChannel, input types.LogLineChannel) {
+// searchDate looks for a date after the beginning of the line, at the start
+// of words only (e.g. in "<34>Oct 11 22:14:15" or "[10/Oct/2000:13:55:36").
+// Unix timestamps are not looked for, as any long number would match.
+func searchDate(line string, hint *int32) *time.Time {
+	// Dates are usually in the same place in every line of a log, so the
+	// format that matched last is looked for on its own first
+	if hint != nil {
+		parser := &dateParsers[atomic.LoadInt32(hint)]
+
+		if date := searchWordStarts(line, parser.parse); date != nil {
+			return date
+		}
+	}
+
+	return searchWordStarts(line, func(text string) *time.Time {
+		return parseLayoutDate(text, hint)
+	})
+}
+
+// searchWordStarts parses the text from the beginning of the words in the
+// line (except the first one) until a date is found
+func searchWordStarts(line string, parse scanFunc) *time.Time {
+	end := len(line)
+
+	if end > maxDateOffset {
+		end = maxDateOffset
+	}
+
+	for i := 1; i < end; i++ {
+		if !isDateStart(line[i]) || isWordChar(line[i-1]) {
+			continue
+		}
+
+		if date := parse(line[i:]); date != nil {
+			return date
+		}
+	}
+
+	return nil
+}
+
+// isDateStart tells if the character can be the first one of a date
+func isDateStart(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z')
+}
+
+func isWordChar(c byte) bool {
+	return isDateStart(c) || (c >= 'a' && c <= 'z') || c == '_'
+}
+
+// ParseDates sets the dates of the lines according to the format, lines
+// without a date inherit the date of the previous line. Dates are converted
+// to the time zone set by SetLocation, so that time fields in filters (like
+// @hour) are in the same time zone as the time interval.
+func ParseDates(output types.LogLineChannel, input types.LogLineChannel, format Format) {
 	var lastDate *time.Time
 
 	for {
@@ -64,9 +179,10 @@ func ParseDates(output types.LogLineChannel, input types.LogLineChannel) {
 			break
 		}
 
-		if date := ParseDate(line.Line); date != nil {
-			line.Date = *date
-			lastDate = date
+		if date := format.ParseDate(line); date != nil {
+			localDate := date.In(getLocation())
+			line.Date = localDate
+			lastDate = &localDate
 		} else if lastDate != nil {
 			line.Date = *lastDate
 		}
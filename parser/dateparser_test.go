@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -32,8 +34,8 @@ func logLine(line string) *types.LogLine {
 }
 
 func TestParseDateFindsDateInLog(t *testing.T) {
-	previousLocationName := defaultLocationName
-	defaultLocationName = "UTC"
+	previousLocation, previousLocationName := location, defaultLocationName
+	location, defaultLocationName = nil, "UTC"
 
 	now := time.Now()
 	exactDate := time.Date(2016, 12, 5, 6, 57, 36, 0, getLocation())
@@ -61,7 +63,7 @@ func TestParseDateFindsDateInLog(t *testing.T) {
 	// This test might fail for two minutes per year:
 	ExpectParsedDate(t, "Dec 31 23:59:58 This is a test log line", yearEnd)
 
-	defaultLocationName = previousLocationName
+	location, defaultLocationName = previousLocation, previousLocationName
 }
 
 func TestParseDatesUsesLastDateIfNoneFound(t *testing.T) {
@@ -69,7 +71,7 @@ func TestParseDatesUsesLastDateIfNoneFound(t *testing.T) {
 	input := types.NewLogLineChannel()
 	output := types.NewLogLineChannel()
 
-	go ParseDates(output, input)
+	go ParseDates(output, input, &GenericFormat{})
 
 	input <- logLine("   ...remnants from previous line")
 	input <- logLine("2016-12-05 06:57:36.000 This is a test log line...")
@@ -80,3 +82,153 @@ func TestParseDatesUsesLastDateIfNoneFound(t *testing.T) {
 
 	close(input)
 }
+
+func TestDateParserSearchesDateInsideLine(t *testing.T) {
+	dates, _ := NewDateParser(DateOptions{Search: true})
+	expected := time.Date(2000, 10, 10, 13, 55, 36, 0, time.FixedZone("", -7*3600))
+
+	for _, line := range []string{
+		`1.2.3.4 - - [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.1" 200 5`,
+		"web-1  | 10/Oct/2000:13:55:36 -0700 listening",
+		"<34>10/Oct/2000:13:55:36 -0700 su: 'su root' failed",
+	} {
+		expectLineDate(t, dates, logLine(line), expected)
+	}
+
+	for _, line := range []string{
+		"request took 1508323200 ms",
+		"job_2016-12-05 06:57:36 started",
+	} {
+		if date := dates.ParseLine(logLine(line)); date != nil {
+			t.Errorf("Line '%s' shouldn't have a date, got '%s'!", line, *date)
+		}
+	}
+}
+
+func TestDatesInsideLineAreIgnoredByDefault(t *testing.T) {
+	dates, _ := NewDateParser(DateOptions{})
+	line := "java.lang.IllegalStateException: token expired at 2016-12-05 06:57:36"
+
+	if date := ParseDate(line); date != nil {
+		t.Errorf("Line '%s' shouldn't have a date, got '%s'!", line, *date)
+	}
+
+	if date := dates.ParseLine(logLine(line)); date != nil {
+		t.Errorf("Line '%s' shouldn't have a date, got '%s'!", line, *date)
+	}
+}
+
+func TestParseDateScansCommonFormats(t *testing.T) {
+	location := getLocation()
+
+	for line, expected := range map[string]time.Time{
+		"2016-12-05 06:57:36.123456 more precise":   time.Date(2016, 12, 5, 6, 57, 36, 123000000, location),
+		"2016-12-05 06:57:36.1 less precise":        time.Date(2016, 12, 5, 6, 57, 36, 0, location),
+		"2016-12-05T06:57:36,123+0100 with zone":    time.Date(2016, 12, 5, 5, 57, 36, 123000000, time.UTC),
+		"Feb 29 06:57:36 2016 in a leap year":       time.Date(2016, 2, 29, 6, 57, 36, 0, location),
+		"dec 05 06:57:36 2016 in lower case":        time.Date(2016, 12, 5, 6, 57, 36, 0, location),
+		"05/Dec/2016:06:57:36 -0130 in access logs": time.Date(2016, 12, 5, 8, 27, 36, 0, time.UTC),
+	} {
+		if date := ParseDate(line); date == nil || !date.Equal(expected) {
+			t.Errorf("Date from line '%s' was parsed as %v instead of '%s'!", line, date, expected)
+		}
+	}
+}
+
+func TestParseDateRejectsInvalidDates(t *testing.T) {
+	for _, line := range []string{
+		"2016-13-05 06:57:36 has no 13th month",
+		"2016-02-30 06:57:36 has no 30th of February",
+		"2016-12-05 24:00:00 has no 24th hour",
+		"Feb 29 06:57:36 2017 is not a leap year",
+		"Foo  5 06:57:36 has no month",
+		"2016-12-05T06:57:36.123 has no zone",
+	} {
+		if date := ParseDate(line); date != nil {
+			t.Errorf("Line '%s' shouldn't have a date, got '%s'!", line, *date)
+		}
+	}
+}
+
+func TestDateParserTriesLastFormatFirst(t *testing.T) {
+	dates, _ := NewDateParser(DateOptions{})
+	expected := time.Date(2016, 12, 5, 6, 57, 36, 0, time.UTC)
+
+	expectLineDate(t, dates, logLine("05/Dec/2016:06:57:36 +0000 first"), expected)
+	expectLineDate(t, dates, logLine("05/Dec/2016:06:57:36 +0000 second"), expected)
+	expectLineDate(t, dates, logLine("2016-12-05T06:57:36.000+0000 in another format"), expected)
+	expectLineDate(t, dates, logLine("05/Dec/2016:06:57:36 +0000 back in the first"), expected)
+}
+
+var benchmarkLines = []string{
+	"2016-12-05 06:57:36,123 INFO [main] Application started in 1234 ms",
+	"Dec  5 06:57:36 myhost sshd[1234]: Accepted publickey for user",
+	`1.2.3.4 - - [05/Dec/2016:06:57:36 +0000] "GET /index.html HTTP/1.1" 200 512`,
+	"\tat com.example.Main.main(Main.java:12)",
+}
+
+func BenchmarkParseDate(b *testing.B) {
+	for _, line := range benchmarkLines {
+		b.Run(line[:10], func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ParseDate(line)
+			}
+		})
+	}
+}
+
+func BenchmarkDateParserParseLine(b *testing.B) {
+	for _, search := range []bool{false, true} {
+		for _, text := range benchmarkLines {
+			dates, _ := NewDateParser(DateOptions{Search: search})
+			line := logLine(text)
+
+			b.Run(fmt.Sprintf("search=%t/%s", search, text[:10]), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					dates.ParseLine(line)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkScanISODate compares the scanner with the regular expression and
+// layout it replaced
+func BenchmarkScanISODate(b *testing.B) {
+	layoutParser := dateParser{
+		reg:          regexp.MustCompile("^(\\d{4}-\\d{2}-\\d{2} \\d{2}:\\d{2}:\\d{2}[.,]\\d{3})"),
+		layout:       "2006-01-02 15:04:05.000",
+		preprocessor: replaceCommaToPoint}
+
+	b.Run("scanner", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			scanISODate(benchmarkLines[0])
+		}
+	})
+
+	b.Run("layout", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			layoutParser.parse(benchmarkLines[0])
+		}
+	})
+}
+
+func TestParseDatesUsesLocation(t *testing.T) {
+	previousLocation := location
+	budapest, _ := time.LoadLocation("Europe/Budapest")
+	SetLocation(budapest)
+
+	input := types.NewLogLineChannel()
+	output := types.NewLogLineChannel()
+
+	go ParseDates(output, input, &GenericFormat{})
+
+	input <- logLine("2016-12-05 06:57:36 without time zone")
+	input <- logLine("05/Dec/2016:06:57:36 +0000 in UTC")
+
+	expectDate(t, (<-output).Date, time.Date(2016, 12, 5, 6, 57, 36, 0, budapest))
+	expectDate(t, (<-output).Date, time.Date(2016, 12, 5, 7, 57, 36, 0, budapest))
+
+	close(input)
+	SetLocation(previousLocation)
+}
@@ -0,0 +1,242 @@
+package parser
+
+import (
+	"strings"
+	"time"
+)
+
+// scanFunc parses the date at the beginning of the text without regular
+// expressions, it returns nil if there's none
+type scanFunc func(text string) *time.Time
+
+// dateScanner reads the parts of a date one by one, once a part is missing
+// every following read fails too
+type dateScanner struct {
+	text   string
+	pos    int
+	failed bool
+}
+
+// number reads an unsigned number of exactly the given digits
+func (s *dateScanner) number(digits int) int {
+	if s.failed || s.pos+digits > len(s.text) {
+		s.failed = true
+		return 0
+	}
+
+	value := 0
+
+	for _, c := range []byte(s.text[s.pos : s.pos+digits]) {
+		if c < '0' || c > '9' {
+			s.failed = true
+			return 0
+		}
+
+		value = value*10 + int(c-'0')
+	}
+
+	s.pos += digits
+	return value
+}
+
+// next tells if the next character is one of chars
+func (s *dateScanner) next(chars string) bool {
+	return !s.failed && s.pos < len(s.text) && strings.IndexByte(chars, s.text[s.pos]) >= 0
+}
+
+// char reads a character, which has to be one of chars
+func (s *dateScanner) char(chars string) byte {
+	if !s.next(chars) {
+		s.failed = true
+		return 0
+	}
+
+	s.pos++
+	return s.text[s.pos-1]
+}
+
+// month reads the three letter abbreviation of a month, in any case
+func (s *dateScanner) month() time.Month {
+	if s.failed || s.pos+3 > len(s.text) {
+		s.failed = true
+		return 0
+	}
+
+	name := s.text[s.pos : s.pos+3]
+
+	for month := time.January; month <= time.December; month++ {
+		if strings.EqualFold(name, month.String()[:3]) {
+			s.pos += 3
+			return month
+		}
+	}
+
+	s.failed = true
+	return 0
+}
+
+// clock reads a time of day in the form 15:04:05
+func (s *dateScanner) clock() (hour, minute, second int) {
+	hour = s.number(2)
+	s.char(":")
+	minute = s.number(2)
+	s.char(":")
+	second = s.number(2)
+
+	return
+}
+
+// millis reads the milliseconds after the seconds (e.g. .123 or ,123) if
+// they're present, other fractions are ignored
+func (s *dateScanner) millis() int {
+	if !s.next(".,") {
+		return 0
+	}
+
+	fraction := &dateScanner{text: s.text, pos: s.pos + 1}
+	value := fraction.number(3)
+
+	if fraction.failed {
+		return 0
+	}
+
+	s.pos = fraction.pos
+	return value * int(time.Millisecond)
+}
+
+// zone reads a numeric time zone offset (e.g. -0700) and returns it in
+// seconds
+func (s *dateScanner) zone() int {
+	sign := s.char("+-")
+	hours := s.number(2)
+	minutes := s.number(2)
+
+	offset := hours*3600 + minutes*60
+
+	if sign == '-' {
+		offset = -offset
+	}
+
+	return offset
+}
+
+// date creates the scanned date in the given location, nil if any of the
+// parts were missing or out of range. Dates without a year are completed
+// the same way as the ones parsed with time.Parse.
+func (s *dateScanner) date(year int, month time.Month, day, hour, minute, second, nanos int, loc *time.Location) *time.Time {
+	if s.failed || month < time.January || month > time.December || day < 1 ||
+		day > daysIn(month, year) || hour > 23 || minute > 59 || second > 59 {
+		return nil
+	}
+
+	date := completeYear(time.Date(year, month, day, hour, minute, second, nanos, loc))
+	return &date
+}
+
+// dateWithOffset creates the scanned date with a numeric time zone offset,
+// in the local time zone if it has the same offset (as time.Parse does)
+func (s *dateScanner) dateWithOffset(year int, month time.Month, day, hour, minute, second, nanos, offset int) *time.Time {
+	utc := s.date(year, month, day, hour, minute, second, nanos, time.UTC)
+
+	if utc == nil {
+		return nil
+	}
+
+	date := utc.Add(-time.Duration(offset) * time.Second).In(getLocation())
+
+	if _, localOffset := date.Zone(); localOffset != offset {
+		date = date.In(time.FixedZone("", offset))
+	}
+
+	return &date
+}
+
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// scanISODate scans dates like 2006-01-02 15:04:05 or 2006-01-02
+// 15:04:05.000 in the local time zone, anything after them is ignored
+func scanISODate(text string) *time.Time {
+	s := &dateScanner{text: text}
+
+	year := s.number(4)
+	s.char("-")
+	month := s.number(2)
+	s.char("-")
+	day := s.number(2)
+	s.char(" ")
+	hour, minute, second := s.clock()
+
+	return s.date(year, time.Month(month), day, hour, minute, second, s.millis(), getLocation())
+}
+
+// scanISODateWithZone scans dates like 2006-01-02T15:04:05.000-0700
+func scanISODateWithZone(text string) *time.Time {
+	s := &dateScanner{text: text}
+
+	year := s.number(4)
+	s.char("-")
+	month := s.number(2)
+	s.char("-")
+	day := s.number(2)
+	s.char("T")
+	hour, minute, second := s.clock()
+	s.char(".,")
+	millis := s.number(3)
+	offset := s.zone()
+
+	return s.dateWithOffset(year, time.Month(month), day, hour, minute, second,
+		millis*int(time.Millisecond), offset)
+}
+
+// scanSyslogDate scans dates like Jan  2 15:04:05 with an optional year
+// after the time
+func scanSyslogDate(text string) *time.Time {
+	s := &dateScanner{text: text}
+
+	month := s.month()
+	s.char(" ")
+
+	// The day is either padded with a space, or is one or two digits long
+	if s.next(" ") {
+		s.pos++
+	}
+
+	day := s.number(1)
+	if s.next("0123456789") {
+		day = day*10 + s.number(1)
+	}
+
+	s.char(" ")
+	hour, minute, second := s.clock()
+
+	year := 0
+	if s.next(" ") {
+		yearScanner := &dateScanner{text: s.text, pos: s.pos + 1}
+
+		if value := yearScanner.number(4); !yearScanner.failed {
+			year = value
+		}
+	}
+
+	return s.date(year, month, day, hour, minute, second, 0, getLocation())
+}
+
+// scanAccessLogDate scans dates like 02/Jan/2006:15:04:05 -0700, used in
+// the access logs of web servers
+func scanAccessLogDate(text string) *time.Time {
+	s := &dateScanner{text: text}
+
+	day := s.number(2)
+	s.char("/")
+	month := s.month()
+	s.char("/")
+	year := s.number(4)
+	s.char(":")
+	hour, minute, second := s.clock()
+	s.char(" ")
+	offset := s.zone()
+
+	return s.dateWithOffset(year, month, day, hour, minute, second, 0, offset)
+}
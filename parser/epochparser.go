@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// epochMatcher matches Unix timestamps at the beginning of the line, with
+// an optional fraction (e.g. 1508323200.123 or 1508323200123)
+var epochMatcher = regexp.MustCompile(`^(\d{10,19})(?:[.,](\d{1,9}))?(?:[^\w.,]|$)`)
+
+var epochUnits = map[string]time.Duration{
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+// ParseEpochUnit returns the unit of Unix timestamps with the given name,
+// 0 (guessing the unit from the magnitude) if the name is empty
+func ParseEpochUnit(name string) (time.Duration, error) {
+	if name == "" {
+		return 0, nil
+	}
+
+	unit, found := epochUnits[strings.ToLower(name)]
+
+	if !found {
+		return 0, fmt.Errorf("unknown epoch unit '%s' (available units: s, ms, us, ns)", name)
+	}
+
+	return unit, nil
+}
+
+// minGuessedEpoch is the earliest date accepted from timestamps whose unit
+// is guessed. Lines starting with other long numbers (IDs, counters, phone
+// numbers, or the well-known 1234567890) would get dates otherwise. Later
+// than a year from now is not accepted either.
+var minGuessedEpoch = time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// guessEpochUnit tells the unit of a timestamp by its number of digits:
+// current dates are 10 digits long in seconds, 13 in milliseconds, 16 in
+// microseconds and 19 in nanoseconds
+func guessEpochUnit(digits int) time.Duration {
+	switch {
+	case digits <= 11:
+		return time.Second
+	case digits <= 14:
+		return time.Millisecond
+	case digits <= 17:
+		return time.Microsecond
+	}
+
+	return time.Nanosecond
+}
+
+// parseEpoch parses the Unix timestamp at the beginning of the line, its
+// unit is guessed if unit is 0. Timestamps with a guessed unit are only
+// accepted between minGuessedEpoch and a year from now.
+func parseEpoch(line string, unit time.Duration) *time.Time {
+	match := epochMatcher.FindStringSubmatch(line)
+
+	if match == nil {
+		return nil
+	}
+
+	guessed := unit == 0
+
+	if guessed {
+		unit = guessEpochUnit(len(match[1]))
+	}
+
+	value, err := strconv.ParseInt(match[1], 10, 64)
+
+	if err != nil || value > math.MaxInt64/int64(unit) {
+		return nil
+	}
+
+	nanos := value * int64(unit)
+
+	if match[2] != "" {
+		// The fraction is parsed as nanoseconds, then scaled to the unit
+		fraction, _ := strconv.ParseInt(match[2]+strings.Repeat("0", 9-len(match[2])), 10, 64)
+		nanos += fraction * int64(unit) / int64(time.Second)
+	}
+
+	date := time.Unix(0, nanos).In(getLocation())
+
+	if guessed && (date.Before(minGuessedEpoch) || date.After(time.Now().AddDate(1, 0, 0))) {
+		return nil
+	}
+
+	return &date
+}
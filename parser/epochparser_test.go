@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateGuessesEpochUnit(t *testing.T) {
+	expected := time.Date(2017, 10, 18, 10, 40, 0, 123000000, time.UTC)
+
+	for _, line := range []string{
+		"1508323200.123 GET /index.html",
+		"1508323200,123",
+		"1508323200123 GET /index.html",
+		"1508323200123000 GET /index.html",
+		"1508323200123000000 GET /index.html",
+		"1508323200123.0 GET /index.html",
+	} {
+		if date := ParseDate(line); date == nil || !date.Equal(expected) {
+			t.Errorf("Date from line '%s' was parsed as %v instead of '%s'!", line, date, expected)
+		}
+	}
+}
+
+func TestParseDateIgnoresNonEpochNumbers(t *testing.T) {
+	for _, line := range []string{
+		"150832320 is too short",
+		"15083232001234567890 is too long",
+		"1508323200abc is not a number",
+		"1508323200.123.4 is a version",
+		"1234567890 foo bar",
+		"0123456789 is an ID",
+		"06301234567 is a phone number",
+		"9999999999999 is a counter",
+	} {
+		if date := ParseDate(line); date != nil {
+			t.Errorf("Line '%s' shouldn't have a date, got '%s'!", line, *date)
+		}
+	}
+}
+
+func TestDateParserUsesEpochUnit(t *testing.T) {
+	dates, err := NewDateParser(DateOptions{EpochUnit: "ms"})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := time.Unix(4013923, 200500000)
+	expectLineDate(t, dates, logLine("4013923200.5 GET /index.html"), expected)
+
+	if date := dates.ParseValue("4013923200.5"); date == nil || !date.Equal(expected) {
+		t.Errorf("Date value was parsed as %v instead of '%s'!", date, expected)
+	}
+
+	// Any date is accepted when the unit is set
+	dates, _ = NewDateParser(DateOptions{EpochUnit: "s"})
+	expectLineDate(t, dates, logLine("1234567890 foo bar"), time.Unix(1234567890, 0))
+
+	if _, err := NewDateParser(DateOptions{EpochUnit: "fortnight"}); err == nil {
+		t.Errorf("Epoch unit 'fortnight' should be invalid!")
+	}
+}
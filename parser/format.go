@@ -0,0 +1,175 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+// Format describes how the lines of a log are split into columns and where
+// their dates come from
+type Format interface {
+	// ParseColumns sets the columns (and their names, if any) of the line
+	ParseColumns(line *types.LogLine)
+	// ParseDate returns the date of a line that has already been split into
+	// columns, nil if it has none
+	ParseDate(line *types.LogLine) *time.Time
+}
+
+// FormatOptions holds the settings of a format
+type FormatOptions struct {
+	// TimeKey is the name of the field holding the date of the line
+	TimeKey string
+	// Fields are the names of the columns by position, empty names (or
+	// "-") leave the column unnamed
+	Fields []string
+	// Dates parses the dates of the lines, nil for the built-in date formats
+	Dates *DateParser
+}
+
+// defaultTimeKeys are the names of the fields tried in order for formats
+// with named fields when no time key is configured
+var defaultTimeKeys = []string{"time", "ts", "timestamp", "@timestamp"}
+
+// headerFormat is implemented by formats whose logs may contain header
+// lines, which are left out from the output. The header is detected from
+// the first two lines of the log, in the order they are read.
+type headerFormat interface {
+	// MayBeHeader tells if the first line of the log may be its header
+	MayBeHeader(line *types.LogLine) bool
+	// DetectHeader tells if first is the header judging by the line after
+	// it, the columns of the following lines are named after the header
+	DetectHeader(first, next *types.LogLine) bool
+	// IsHeader tells if the line is a repeated header
+	IsHeader(line *types.LogLine) bool
+}
+
+type formatFactory func(options FormatOptions) (Format, error)
+
+var formatFactories = map[string]formatFactory{}
+
+func init() {
+	formatFactories["generic"] = func(options FormatOptions) (Format, error) {
+		return &GenericFormat{dates: options.Dates}, nil
+	}
+}
+
+// GetFormat returns the format with the given name
+func GetFormat(name string, options FormatOptions) (Format, error) {
+	factory, found := formatFactories[name]
+
+	if !found {
+		return nil, fmt.Errorf("unknown log format '%s' (available formats: %s)",
+			name, strings.Join(FormatNames(), ", "))
+	}
+
+	format, err := factory(options)
+
+	if err != nil || len(options.Fields) == 0 {
+		return format, err
+	}
+
+	return NewSchemaFormat(format, options.Fields, options.TimeKey, options.Dates), nil
+}
+
+// FormatNames returns the names of the available formats
+func FormatNames() []string {
+	names := []string{}
+
+	for name := range formatFactories {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ParseLineDate parses a single raw line and returns its date, the line may
+// end with a newline character
+func ParseLineDate(format Format, line string) *time.Time {
+	logLine := &types.LogLine{Line: strings.TrimSuffix(line, "\n")}
+	format.ParseColumns(logLine)
+
+	return format.ParseDate(logLine)
+}
+
+// GenericFormat splits lines on whitespace and looks for the date at the
+// beginning of the line
+type GenericFormat struct {
+	dates *DateParser
+}
+
+func (f *GenericFormat) ParseColumns(line *types.LogLine) {
+	line.Columns = splitColumns(line.Line)
+}
+
+func (f *GenericFormat) ParseDate(line *types.LogLine) *time.Time {
+	return f.dates.ParseLine(line)
+}
+
+// addColumn appends a named column to the line's columns
+func addColumn(columns types.ColumnList, names types.ColumnNames, name, value string) {
+	column := len(columns) + 1
+	columns[column] = value
+	names[name] = column
+}
+
+// namesOf names the columns after the fields by their positions, empty
+// names (or "-") leave the column unnamed
+func namesOf(fields []string) types.ColumnNames {
+	names := types.ColumnNames{}
+
+	for i, field := range fields {
+		if field != "" && field != "-" {
+			names[strings.TrimSpace(field)] = i + 1
+		}
+	}
+
+	return names
+}
+
+// timeKeys returns the keys to look for the date in, the default ones if
+// timeKey is empty
+func timeKeys(timeKey string) []string {
+	if timeKey == "" {
+		return defaultTimeKeys
+	}
+
+	return []string{strings.TrimPrefix(timeKey, ".")}
+}
+
+// parseDateFromKeys parses the date from the first of the named fields
+// present in the line
+func parseDateFromKeys(line *types.LogLine, keys []string, dates *DateParser) *time.Time {
+	for _, key := range keys {
+		if value, found := line.Field(key); found {
+			return dates.ParseValue(value)
+		}
+	}
+
+	return nil
+}
+
+// ParseDateValue parses a date stored in a field of its own, which can be
+// in RFC 3339 format as well as any of the formats found in log lines
+func ParseDateValue(value string) *time.Time {
+	return parseDateValue(value, 0, nil, true)
+}
+
+func parseDateValue(value string, epochUnit time.Duration, hint *int32, epochs bool) *time.Time {
+	value = strings.TrimSpace(value)
+
+	if date, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return &date
+	}
+
+	if !epochs {
+		return parseLayoutDate(value, hint)
+	}
+
+	return parseDateAtStart(value, epochUnit, hint)
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+func init() {
+	formatFactories["json"] = func(options FormatOptions) (Format, error) {
+		return NewJSONFormat(options.TimeKey, options.Dates), nil
+	}
+}
+
+// JSONFormat parses lines containing one JSON object each. Every scalar
+// value becomes a column named by its key path (e.g. request.status for
+// {"request": {"status": 200}}), array elements are addressed by their
+// index. Lines that aren't JSON objects are parsed as generic lines.
+type JSONFormat struct {
+	timeKeys []string
+	dates    *DateParser
+}
+
+// NewJSONFormat creates a JSON format taking the date from the given key,
+// or from one of the common time keys if it's empty
+func NewJSONFormat(timeKey string, dates *DateParser) *JSONFormat {
+	return &JSONFormat{timeKeys: timeKeys(timeKey), dates: dates}
+}
+
+func (f *JSONFormat) ParseColumns(line *types.LogLine) {
+	trimmed := strings.TrimSpace(line.Line)
+
+	if !strings.HasPrefix(trimmed, "{") {
+		line.Columns = splitColumns(line.Line)
+		return
+	}
+
+	columns := types.ColumnList{}
+	names := types.ColumnNames{}
+
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+
+	if err := addJSONValue(decoder, "", columns, names); err != nil {
+		line.Columns = splitColumns(line.Line)
+		return
+	}
+
+	line.Columns = columns
+	line.Names = names
+}
+
+// addJSONValue reads the next value from the decoder and stores its scalar
+// values as columns prefixed by path
+func addJSONValue(decoder *json.Decoder, path string, columns types.ColumnList, names types.ColumnNames) error {
+	token, err := decoder.Token()
+
+	if err != nil {
+		return err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		for index := 0; decoder.More(); index++ {
+			key := fmt.Sprintf("%d", index)
+
+			if value == '{' {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+
+				key = keyToken.(string)
+			}
+
+			if path != "" {
+				key = path + "." + key
+			}
+
+			if err := addJSONValue(decoder, key, columns, names); err != nil {
+				return err
+			}
+		}
+
+		// Consume the closing delimiter
+		_, err := decoder.Token()
+		return err
+
+	case string:
+		addColumn(columns, names, path, value)
+
+	case json.Number:
+		addColumn(columns, names, path, value.String())
+
+	case bool:
+		addColumn(columns, names, path, fmt.Sprintf("%t", value))
+
+	case nil:
+		addColumn(columns, names, path, "null")
+	}
+
+	return nil
+}
+
+func (f *JSONFormat) ParseDate(line *types.LogLine) *time.Time {
+	return parseDateFromKeys(line, f.timeKeys, f.dates)
+}
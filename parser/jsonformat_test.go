@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+func parseJSONLine(timeKey, text string) *types.LogLine {
+	line := &types.LogLine{Line: text}
+	NewJSONFormat(timeKey, nil).ParseColumns(line)
+	return line
+}
+
+func expectField(t *testing.T, line *types.LogLine, name, expected string) {
+	value, found := line.Field(name)
+
+	if !found {
+		t.Errorf("Field %s not found in line %s", name, line.Line)
+	} else if value != expected {
+		t.Errorf("Field %s (%s) != expected (%s)", name, value, expected)
+	}
+}
+
+func TestJSONFieldsAreAddressedByKeyPath(t *testing.T) {
+	line := parseJSONLine("", `{"level": "info", "request": {"status": 200, "path": "/api"}, `+
+		`"user": {"id": "u1", "admin": false}, "tags": ["a", "b"], "error": null}`)
+
+	expectField(t, line, "level", "info")
+	expectField(t, line, "request.status", "200")
+	expectField(t, line, "request.path", "/api")
+	expectField(t, line, "user.id", "u1")
+	expectField(t, line, "user.admin", "false")
+	expectField(t, line, "tags.1", "b")
+	expectField(t, line, "error", "null")
+
+	if line.Columns[1] != "info" || line.Columns[2] != "200" {
+		t.Errorf("Columns should follow the order of the keys, got %v", line.Columns)
+	}
+}
+
+func TestJSONNumbersAreKeptAsWritten(t *testing.T) {
+	line := parseJSONLine("", `{"duration": 1.50, "big": 12345678901234567890}`)
+
+	expectField(t, line, "duration", "1.50")
+	expectField(t, line, "big", "12345678901234567890")
+}
+
+func TestNonJSONLinesAreParsedAsGenericLines(t *testing.T) {
+	for _, text := range []string{"plain text line", `{"unterminated": "object"`} {
+		line := parseJSONLine("", text)
+
+		if len(line.Names) != 0 || len(line.Columns) == 0 {
+			t.Errorf("Line %s should have been split as a generic line, got %v", text, line.Columns)
+		}
+	}
+}
+
+func TestJSONDateIsTakenFromTheTimeKey(t *testing.T) {
+	expected := time.Date(2017, 2, 26, 8, 0, 5, 123000000, time.UTC)
+
+	line := parseJSONLine("", `{"msg": "hello", "ts": "2017-02-26T08:00:05.123Z"}`)
+	if date := NewJSONFormat("", nil).ParseDate(line); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+
+	line = parseJSONLine("meta.at", `{"time": "garbage", "meta": {"at": "2017-02-26T08:00:05.123Z"}}`)
+	if date := NewJSONFormat(".meta.at", nil).ParseDate(line); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+
+	line = parseJSONLine("", `{"msg": "no date"}`)
+	if date := NewJSONFormat("", nil).ParseDate(line); date != nil {
+		t.Errorf("Line without a date returned %v", date)
+	}
+}
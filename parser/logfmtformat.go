@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+func init() {
+	formatFactories["logfmt"] = func(options FormatOptions) (Format, error) {
+		return NewLogfmtFormat(options.TimeKey, options.Dates), nil
+	}
+}
+
+// LogfmtFormat parses lines of key=value pairs (e.g. level=info msg="done"),
+// every pair becomes a column named by its key. Values can be quoted, quoted
+// values may contain escaped quotes. Keys without a value get an empty one.
+// Lines without any key=value pairs are parsed as generic lines.
+type LogfmtFormat struct {
+	timeKeys []string
+	dates    *DateParser
+}
+
+// NewLogfmtFormat creates a logfmt format taking the date from the given
+// key, or from one of the common time keys if it's empty
+func NewLogfmtFormat(timeKey string, dates *DateParser) *LogfmtFormat {
+	return &LogfmtFormat{timeKeys: timeKeys(timeKey), dates: dates}
+}
+
+func (f *LogfmtFormat) ParseColumns(line *types.LogLine) {
+	columns := types.ColumnList{}
+	names := types.ColumnNames{}
+	text := line.Line
+	pairs := 0
+
+	for pos := 0; pos < len(text); {
+		if isWhitespace(text[pos]) {
+			pos++
+			continue
+		}
+
+		keyStart := pos
+		for pos < len(text) && text[pos] != '=' && !isWhitespace(text[pos]) {
+			pos++
+		}
+
+		key := text[keyStart:pos]
+		value := ""
+
+		if pos < len(text) && text[pos] == '=' {
+			value, pos = readLogfmtValue(text, pos+1)
+			pairs++
+		}
+
+		addColumn(columns, names, key, value)
+	}
+
+	if pairs == 0 {
+		line.Columns = splitColumns(line.Line)
+		return
+	}
+
+	line.Columns = columns
+	line.Names = names
+}
+
+// readLogfmtValue reads a (possibly quoted) value starting at pos, returns
+// the value and the position after it
+func readLogfmtValue(text string, pos int) (string, int) {
+	if pos >= len(text) || text[pos] != '"' {
+		start := pos
+		for pos < len(text) && !isWhitespace(text[pos]) {
+			pos++
+		}
+
+		return text[start:pos], pos
+	}
+
+	start := pos
+	escaped := false
+
+	for pos++; pos < len(text); pos++ {
+		if escaped {
+			escaped = false
+		} else if text[pos] == '\\' {
+			escaped = true
+		} else if text[pos] == '"' {
+			pos++
+			break
+		}
+	}
+
+	quoted := text[start:pos]
+
+	if value, err := strconv.Unquote(quoted); err == nil {
+		return value, pos
+	}
+
+	// Unterminated or invalid quoted value, keep what's between the quotes
+	return strings.TrimSuffix(quoted[1:], "\""), pos
+}
+
+func (f *LogfmtFormat) ParseDate(line *types.LogLine) *time.Time {
+	return parseDateFromKeys(line, f.timeKeys, f.dates)
+}
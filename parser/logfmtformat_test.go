@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+func parseLogfmtLine(text string) *types.LogLine {
+	line := &types.LogLine{Line: text}
+	NewLogfmtFormat("", nil).ParseColumns(line)
+	return line
+}
+
+func TestLogfmtPairsBecomeNamedFields(t *testing.T) {
+	line := parseLogfmtLine(`level=info msg="request done" dur=12ms  path=/api/v1`)
+
+	expectField(t, line, "level", "info")
+	expectField(t, line, "msg", "request done")
+	expectField(t, line, "dur", "12ms")
+	expectField(t, line, "path", "/api/v1")
+
+	if len(line.Columns) != 4 || line.Columns[2] != "request done" {
+		t.Errorf("Columns should follow the order of the pairs, got %v", line.Columns)
+	}
+}
+
+func TestLogfmtQuotedValuesCanContainEscapes(t *testing.T) {
+	line := parseLogfmtLine(`msg="say \"hello\" to a=b" err="line1\nline2" path="C:\\temp" empty=""`)
+
+	expectField(t, line, "msg", `say "hello" to a=b`)
+	expectField(t, line, "err", "line1\nline2")
+	expectField(t, line, "path", `C:\temp`)
+	expectField(t, line, "empty", "")
+}
+
+func TestLogfmtHandlesMalformedPairs(t *testing.T) {
+	line := parseLogfmtLine(`debug level= msg="unterminated`)
+
+	expectField(t, line, "debug", "")
+	expectField(t, line, "level", "")
+	expectField(t, line, "msg", "unterminated")
+}
+
+func TestNonLogfmtLinesAreParsedAsGenericLines(t *testing.T) {
+	line := parseLogfmtLine(`plain "text line"`)
+
+	if len(line.Names) != 0 || line.Columns[2] != "text line" {
+		t.Errorf("Line should have been split as a generic line, got %v", line.Columns)
+	}
+}
+
+func TestLogfmtDateIsTakenFromTheTimeKey(t *testing.T) {
+	expected := time.Date(2017, 2, 26, 8, 0, 5, 0, time.UTC)
+	line := parseLogfmtLine(`ts=2017-02-26T08:00:05Z level=info`)
+
+	if date := NewLogfmtFormat("", nil).ParseDate(line); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+}
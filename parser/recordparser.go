@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"regexp"
+
+	"github.com/kbence/logan/types"
+)
+
+// maxContinuationLines limits the size of a record, further lines start a
+// new one
+const maxContinuationLines = 1000
+
+// RecordStartFunc tells if a line starts a new record
+type RecordStartFunc func(line string) bool
+
+// NewRecordStartFunc returns a function that detects the first lines of
+// records: lines matching the pattern or, if it's empty, lines having a date
+func NewRecordStartFunc(format Format, pattern string) (RecordStartFunc, error) {
+	if pattern == "" {
+		return func(line string) bool {
+			return ParseLineDate(format, line) != nil
+		}, nil
+	}
+
+	reg, err := regexp.Compile(pattern)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reg.MatchString, nil
+}
+
+// FoldRecords groups lines into records, lines not starting a new record
+// (e.g. the lines of a stack trace) are added to the preceding one as
+// continuation lines
+func FoldRecords(output types.LogLineChannel, input types.LogLineChannel, isRecordStart RecordStartFunc) {
+	var record *types.LogLine
+
+	for {
+		line, more := <-input
+
+		if !more {
+			break
+		}
+
+		if record != nil && len(record.Continuation) < maxContinuationLines && !isRecordStart(line.Line) {
+			record.Continuation = append(record.Continuation, line.Line)
+			continue
+		}
+
+		if record != nil {
+			output <- record
+		}
+
+		record = line
+	}
+
+	if record != nil {
+		output <- record
+	}
+
+	close(output)
+}
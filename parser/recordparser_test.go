@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/kbence/logan/types"
+)
+
+func foldLines(t *testing.T, pattern string, lines ...string) []*types.LogLine {
+	isRecordStart, err := NewRecordStartFunc(&GenericFormat{}, pattern)
+
+	if err != nil {
+		t.Fatalf("Record start function couldn't be created: %s", err)
+	}
+
+	input := make(types.LogLineChannel)
+	output := make(types.LogLineChannel)
+
+	go func() {
+		for _, line := range lines {
+			input <- &types.LogLine{Line: line}
+		}
+		close(input)
+	}()
+
+	go FoldRecords(output, input, isRecordStart)
+
+	records := []*types.LogLine{}
+	for record := range output {
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestLinesWithoutDateAreFoldedIntoRecords(t *testing.T) {
+	records := foldLines(t, "",
+		"orphan line",
+		"2017-02-26 08:00:05 ERROR failed",
+		"java.lang.IllegalStateException: token expired at 2016-12-05 06:57:36",
+		"\tat Main.run(Main.java:10)",
+		"2017-02-26 08:00:06 INFO done")
+
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+
+	if records[0].Line != "orphan line" || len(records[0].Continuation) != 0 {
+		t.Errorf("Lines before the first record should be kept on their own, got %v", records[0])
+	}
+
+	expected := "2017-02-26 08:00:05 ERROR failed\n" +
+		"java.lang.IllegalStateException: token expired at 2016-12-05 06:57:36\n\tat Main.run(Main.java:10)"
+	if records[1].Text() != expected {
+		t.Errorf("Record text (%q) != expected (%q)", records[1].Text(), expected)
+	}
+
+	if records[2].Line != "2017-02-26 08:00:06 INFO done" || len(records[2].Continuation) != 0 {
+		t.Errorf("Last record is wrong: %q", records[2].Text())
+	}
+}
+
+func TestRecordStartCanBeMatchedByPattern(t *testing.T) {
+	records := foldLines(t, `^\[`,
+		"[main] Traceback (most recent call last):",
+		"  File \"app.py\", line 1",
+		"[main] done")
+
+	if len(records) != 2 || len(records[0].Continuation) != 1 {
+		t.Errorf("Expected 2 records with 1 continuation line in the first one, got %d", len(records))
+	}
+}
+
+func TestInvalidRecordStartPatternIsRejected(t *testing.T) {
+	if _, err := NewRecordStartFunc(&GenericFormat{}, "(unclosed"); err == nil {
+		t.Errorf("Invalid pattern should have been rejected")
+	}
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+// defaultRegexTimeGroup is the name of the group holding the date
+const defaultRegexTimeGroup = "time"
+
+// RegexFormat parses lines with a user-defined regular expression, every
+// named group becomes a column named after the group. Lines not matching
+// the expression are parsed as generic lines.
+type RegexFormat struct {
+	reg        *regexp.Regexp
+	groups     []int
+	names      types.ColumnNames
+	timeKey    string
+	timeLayout string
+	dates      *DateParser
+}
+
+// NewRegexFormat compiles the pattern into a format. The date is taken from
+// the group named by timeKey (or "time" if it's empty) and parsed with
+// timeLayout (see time.Parse), or with the built-in date formats if no
+// layout is given.
+func NewRegexFormat(pattern, timeKey, timeLayout string, dates *DateParser) (*RegexFormat, error) {
+	reg, err := regexp.Compile(pattern)
+
+	if err != nil {
+		return nil, err
+	}
+
+	format := &RegexFormat{reg: reg, names: types.ColumnNames{}, timeKey: timeKey, timeLayout: timeLayout,
+		dates: dates}
+
+	for group, name := range reg.SubexpNames() {
+		if name == "" {
+			continue
+		}
+
+		format.groups = append(format.groups, group)
+		format.names[name] = len(format.groups)
+	}
+
+	if len(format.groups) == 0 {
+		return nil, fmt.Errorf("pattern %s has no named groups", pattern)
+	}
+
+	if format.timeKey == "" {
+		format.timeKey = defaultRegexTimeGroup
+	}
+
+	return format, nil
+}
+
+// RegisterRegexFormat makes a user-defined format available by its name,
+// the pattern is only compiled when the format is used
+func RegisterRegexFormat(name, pattern, timeLayout string) {
+	formatFactories[name] = func(options FormatOptions) (Format, error) {
+		format, err := NewRegexFormat(pattern, options.TimeKey, timeLayout, options.Dates)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid format '%s': %s", name, err)
+		}
+
+		return format, nil
+	}
+}
+
+func (f *RegexFormat) ParseColumns(line *types.LogLine) {
+	match := f.reg.FindStringSubmatchIndex(line.Line)
+
+	if match == nil {
+		line.Columns = splitColumns(line.Line)
+		return
+	}
+
+	columns := types.ColumnList{}
+
+	for i, group := range f.groups {
+		if match[2*group] >= 0 {
+			columns[i+1] = line.Line[match[2*group]:match[2*group+1]]
+		}
+	}
+
+	line.Columns = columns
+	line.Names = f.names
+}
+
+func (f *RegexFormat) ParseDate(line *types.LogLine) *time.Time {
+	value, found := line.Field(f.timeKey)
+
+	if !found {
+		return nil
+	}
+
+	if f.timeLayout == "" {
+		return f.dates.ParseValue(value)
+	}
+
+	date, err := time.ParseInLocation(f.timeLayout, value, getLocation())
+
+	if err != nil {
+		return nil
+	}
+
+	date = completeYear(date)
+	return &date
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+const testRegexPattern = `^(?P<time>\S+ \S+) \[(?P<level>\w+)\] (?:(?P<module>\w+): )?(?P<msg>.*)$`
+
+func TestRegexGroupsBecomeNamedColumns(t *testing.T) {
+	format, err := NewRegexFormat(testRegexPattern, "", "2006-01-02 15:04:05", nil)
+
+	if err != nil {
+		t.Fatalf("Format couldn't be created: %s", err)
+	}
+
+	line := &types.LogLine{Line: "2017-02-26 08:00:05 [WARN] db: slow query (523ms)"}
+	format.ParseColumns(line)
+
+	expectField(t, line, "time", "2017-02-26 08:00:05")
+	expectField(t, line, "level", "WARN")
+	expectField(t, line, "module", "db")
+	expectField(t, line, "msg", "slow query (523ms)")
+
+	if line.Columns[4] != "slow query (523ms)" {
+		t.Errorf("Columns should be numbered by the order of the groups, got %v", line.Columns)
+	}
+
+	line = &types.LogLine{Line: "2017-02-26 08:00:05 [INFO] started"}
+	format.ParseColumns(line)
+
+	if _, found := line.Field("module"); found {
+		t.Errorf("Groups not taking part in the match shouldn't be found")
+	}
+}
+
+func TestRegexFormatFallsBackToGenericLines(t *testing.T) {
+	format, _ := NewRegexFormat(testRegexPattern, "", "", nil)
+	line := &types.LogLine{Line: "something else"}
+	format.ParseColumns(line)
+
+	if len(line.Names) != 0 || line.Columns[2] != "else" {
+		t.Errorf("Line should have been split as a generic line, got %v", line.Columns)
+	}
+}
+
+func TestRegexDateIsParsedWithLayout(t *testing.T) {
+	format, _ := NewRegexFormat(`^(?P<at>\d+/\d+/\d+ \d+\.\d+) (?P<msg>.*)$`, "at", "02/01/2006 15.04", nil)
+	line := &types.LogLine{Line: "26/02/2017 08.05 hello"}
+	format.ParseColumns(line)
+	expected := time.Date(2017, 2, 26, 8, 5, 0, 0, getLocation())
+
+	if date := format.ParseDate(line); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+}
+
+func TestInvalidRegexFormatsAreRejected(t *testing.T) {
+	for _, pattern := range []string{`^(?P<time>\S+`, `^(\S+) (.*)$`} {
+		if _, err := NewRegexFormat(pattern, "", "", nil); err == nil {
+			t.Errorf("Pattern %s should have been rejected", pattern)
+		}
+	}
+
+	RegisterRegexFormat("broken", `(?P<x>`, "")
+
+	if _, err := GetFormat("broken", FormatOptions{}); err == nil {
+		t.Errorf("Broken user-defined format should return an error")
+	}
+}
+
+func TestRegexDateOfRawLineWithNewline(t *testing.T) {
+	format, _ := NewRegexFormat(testRegexPattern, "", "2006-01-02 15:04:05", nil)
+	expected := time.Date(2017, 2, 26, 8, 0, 5, 0, getLocation())
+
+	if date := ParseLineDate(format, "2017-02-26 08:00:05 [INFO] started\n"); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+// SchemaFormat names the columns parsed by another format by their
+// positions, e.g. the 9th column of nginx access logs as status
+type SchemaFormat struct {
+	format  Format
+	names   types.ColumnNames
+	timeKey string
+	dates   *DateParser
+
+	// Formats like combined or regex give every line the same names, which
+	// may be read by other goroutines, so they're never modified. The names
+	// merged with the last ones seen are kept to avoid copying them for
+	// every line.
+	mutex       sync.Mutex
+	lastNames   types.ColumnNames
+	mergedNames types.ColumnNames
+}
+
+// NewSchemaFormat wraps the format so that the columns are named after
+// fields, the date is taken from the timeKey field if it's set and can be
+// parsed
+func NewSchemaFormat(format Format, fields []string, timeKey string, dates *DateParser) *SchemaFormat {
+	return &SchemaFormat{format: format, names: namesOf(fields), timeKey: timeKey, dates: dates}
+}
+
+func (f *SchemaFormat) ParseColumns(line *types.LogLine) {
+	f.format.ParseColumns(line)
+
+	// Lines without names of their own share the names of the schema
+	if line.Names == nil {
+		line.Names = f.names
+		return
+	}
+
+	line.Names = f.mergeNames(line.Names)
+}
+
+// mergeNames returns the names of the format extended with the ones of the
+// schema, without modifying either of them
+func (f *SchemaFormat) mergeNames(names types.ColumnNames) types.ColumnNames {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.lastNames != nil && reflect.ValueOf(f.lastNames).Pointer() == reflect.ValueOf(names).Pointer() {
+		return f.mergedNames
+	}
+
+	merged := types.ColumnNames{}
+
+	for name, column := range names {
+		merged[name] = column
+	}
+
+	for name, column := range f.names {
+		merged[name] = column
+	}
+
+	f.lastNames, f.mergedNames = names, merged
+	return merged
+}
+
+func (f *SchemaFormat) ParseDate(line *types.LogLine) *time.Time {
+	if f.timeKey != "" {
+		if value, found := line.Field(f.timeKey); found {
+			if date := f.dates.ParseValue(value); date != nil {
+				return date
+			}
+		}
+	}
+
+	return f.format.ParseDate(line)
+}
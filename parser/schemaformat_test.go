@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kbence/logan/types"
+)
+
+func TestSchemaNamesColumnsByPosition(t *testing.T) {
+	format := NewSchemaFormat(&GenericFormat{}, []string{"ip", "-", "user", "", "status"}, "", nil)
+	line := &types.LogLine{Line: "10.0.0.1 - alice [time] 200"}
+	format.ParseColumns(line)
+
+	expectField(t, line, "ip", "10.0.0.1")
+	expectField(t, line, "user", "alice")
+	expectField(t, line, "status", "200")
+
+	if len(line.Names) != 3 {
+		t.Errorf("Unnamed columns should be skipped, got names %v", line.Names)
+	}
+
+	line = &types.LogLine{Line: "10.0.0.1 -"}
+	format.ParseColumns(line)
+
+	if _, found := line.Field("status"); found {
+		t.Errorf("Missing columns shouldn't be found by their names")
+	}
+}
+
+func TestSchemaExtendsNamesOfTheFormat(t *testing.T) {
+	format := NewSchemaFormat(NewLogfmtFormat("", nil), []string{"first"}, "", nil)
+	line := &types.LogLine{Line: "level=info msg=done"}
+	format.ParseColumns(line)
+
+	expectField(t, line, "first", "info")
+	expectField(t, line, "level", "info")
+	expectField(t, line, "msg", "done")
+}
+
+func TestSchemaDateIsTakenFromTheTimeKey(t *testing.T) {
+	expected := time.Date(2017, 2, 26, 8, 0, 5, 0, time.UTC)
+	format := NewSchemaFormat(&GenericFormat{}, []string{"host", "time"}, "time", nil)
+	line := &types.LogLine{Line: "server 2017-02-26T08:00:05Z"}
+	format.ParseColumns(line)
+
+	if date := format.ParseDate(line); date == nil || !date.Equal(expected) {
+		t.Errorf("Date (%v) != expected (%v)", date, expected)
+	}
+}
+
+func TestSchemaDoesntModifyNamesOfTheFormat(t *testing.T) {
+	combined := NewCombinedFormat()
+	format := NewSchemaFormat(combined, []string{"client"}, "", nil)
+	text := `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /a.gif HTTP/1.0" 200 2326`
+	done := make(chan bool)
+
+	// Lines are parsed and read concurrently in the pipeline, run with
+	// -race to check that the shared names aren't written
+	go func() {
+		for i := 0; i < 100; i++ {
+			line := &types.LogLine{Line: text}
+			format.ParseColumns(line)
+			expectField(t, line, "client", "127.0.0.1")
+		}
+
+		done <- true
+	}()
+
+	for i := 0; i < 100; i++ {
+		line := &types.LogLine{Line: text}
+		combined.ParseColumns(line)
+		expectField(t, line, "status", "200")
+
+		if _, found := line.Field("client"); found {
+			t.Errorf("Names of the schema were added to the names of the format")
+			break
+		}
+	}
+
+	<-done
+}
@@ -5,9 +5,11 @@ import (
 	"github.com/kbence/logan/types"
 )
 
+// FilterPipeline passes through lines matching all of its filters. Filters
+// are evaluated in the given order in a single stage, so cheaper ones should
+// come first.
 type FilterPipeline struct {
 	filters      []filter.Filter
-	channels     []types.LogLineChannel
 	inputChannel types.LogLineChannel
 }
 
@@ -15,15 +17,25 @@ func NewFilterPipeline(input types.LogLineChannel, filters []filter.Filter) *Fil
 	return &FilterPipeline{filters: filters, inputChannel: input}
 }
 
-func filterFunc(output types.LogLineChannel, input types.LogLineChannel, f filter.Filter) {
+func (p *FilterPipeline) matches(line *types.LogLine) bool {
+	for _, f := range p.filters {
+		if !f.Match(line) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *FilterPipeline) filter(output types.LogLineChannel) {
 	for {
-		line, more := <-input
+		line, more := <-p.inputChannel
 
 		if !more {
 			break
 		}
 
-		if f.Match(line) {
+		if p.matches(line) {
 			output <- line
 		}
 	}
@@ -32,13 +44,8 @@ func filterFunc(output types.LogLineChannel, input types.LogLineChannel, f filte
 }
 
 func (p *FilterPipeline) Start() types.LogLineChannel {
-	inputChannel := p.inputChannel
-
-	for _, f := range p.filters {
-		outputChannel := types.NewLogLineChannel()
-		go filterFunc(outputChannel, inputChannel, f)
-		inputChannel = outputChannel
-	}
+	outputChannel := types.NewLogLineChannel()
+	go p.filter(outputChannel)
 
-	return inputChannel
+	return outputChannel
 }
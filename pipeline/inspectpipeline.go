@@ -21,7 +21,18 @@ func (p *InspectPipeline) printInspector(line *types.LogLine, id int) {
 
 	fmt.Printf("Line: %s\n", line.Line)
 
+	for _, continuation := range line.Continuation {
+		fmt.Printf("      %s\n", continuation)
+	}
+
+	names := line.Names.ByColumn()
+
 	for _, key := range line.Columns.SortedKeys() {
+		if name, found := names[key]; found {
+			fmt.Printf("%4d %s: %s\n", key+1, name, line.Columns[key])
+			continue
+		}
+
 		fmt.Printf("%4s: %s\n", fmt.Sprintf("%d", key+1), line.Columns[key])
 	}
 }
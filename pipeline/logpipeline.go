@@ -9,13 +9,18 @@ import (
 
 type LogPipeline struct {
 	reader        io.Reader
+	format        parser.Format
+	recordStart   parser.RecordStartFunc
 	lineChannel   types.LogLineChannel
+	recordChannel types.LogLineChannel
 	dateChannel   types.LogLineChannel
 	columnChannel types.LogLineChannel
 }
 
-func NewLogPipeline(reader io.Reader) *LogPipeline {
-	return &LogPipeline{reader: reader}
+// NewLogPipeline creates the pipeline parsing the lines of the reader, if
+// recordStart is set, lines are grouped into multi-line records
+func NewLogPipeline(reader io.Reader, format parser.Format, recordStart parser.RecordStartFunc) *LogPipeline {
+	return &LogPipeline{reader: reader, format: format, recordStart: recordStart}
 }
 
 func (p *LogPipeline) Start() types.LogLineChannel {
@@ -23,9 +28,17 @@ func (p *LogPipeline) Start() types.LogLineChannel {
 	p.dateChannel = types.NewLogLineChannel()
 	p.columnChannel = types.NewLogLineChannel()
 
-	go parser.ParseColumns(p.columnChannel, p.dateChannel)
-	go parser.ParseDates(p.dateChannel, p.lineChannel)
+	p.recordChannel = p.lineChannel
+
+	if p.recordStart != nil {
+		p.recordChannel = types.NewLogLineChannel()
+		go parser.FoldRecords(p.recordChannel, p.lineChannel, p.recordStart)
+	}
+
+	// Columns are parsed first, as some formats take the date from a column
+	go parser.ParseDates(p.dateChannel, p.columnChannel, p.format)
+	go parser.ParseColumns(p.columnChannel, p.recordChannel, p.format)
 	go parser.ParseLines(p.lineChannel, p.reader)
 
-	return p.columnChannel
+	return p.dateChannel
 }
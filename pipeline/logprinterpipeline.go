@@ -1,6 +1,10 @@
 package pipeline
 
-import "github.com/kbence/logan/types"
+import (
+	"fmt"
+
+	"github.com/kbence/logan/types"
+)
 
 type LogPrinterPipeline struct {
 	input types.LogLineChannel
@@ -22,6 +26,10 @@ func (p *LogPrinterPipeline) Start() chan bool {
 			}
 
 			printColumnsInOrder(line.Columns)
+
+			for _, continuation := range line.Continuation {
+				fmt.Println(continuation)
+			}
 		}
 
 		exitChannel <- true
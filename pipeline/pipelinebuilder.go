@@ -3,9 +3,11 @@ package pipeline
 import (
 	"log"
 	"strings"
+	"time"
 
 	"github.com/kbence/logan/config"
 	"github.com/kbence/logan/filter"
+	"github.com/kbence/logan/parser"
 	"github.com/kbence/logan/source"
 	"github.com/kbence/logan/types"
 )
@@ -21,6 +23,10 @@ const (
 
 type PipelineSettings struct {
 	Category       string
+	Format         string
+	Multiline      bool
+	DateFormats    []string
+	Location       *time.Location
 	Interval       *types.TimeInterval
 	Filters        []string
 	Fields         []*types.IntInterval
@@ -72,20 +78,144 @@ func (p *PipelineBuilder) getChain() (chain source.LogChain) {
 	return chain
 }
 
-func (p *PipelineBuilder) Execute() {
-	chain := p.getChain()
-
+// compileFilters merges all the filter expressions into a single filter,
+// the time filter is checked first as it's the cheapest
+func (p *PipelineBuilder) compileFilters() ([]filter.Filter, error) {
 	filters := []filter.Filter{
 		filter.NewTimeFilter(p.settings.Interval),
 	}
 
-	for _, filterString := range p.settings.Filters {
-		columnFilter := filter.NewColumnFilter(filterString)
+	columnFilter, err := filter.Compile(p.settings.Filters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if columnFilter != nil {
 		filters = append(filters, columnFilter)
 	}
 
+	return filters, nil
+}
+
+// GetCategorySettings returns the settings of the category from the
+// configuration, short category names are looked up by their full names
+func GetCategorySettings(cfg *config.Configuration, category string) *config.CategorySettings {
+	if settings, found := cfg.Categories[category]; found {
+		return settings
+	}
+
+	if strings.Count(category, "/") == 0 {
+		for name, logSource := range source.GetLogSources(cfg) {
+			if !logSource.ContainsCategory(category) {
+				continue
+			}
+
+			if settings, found := cfg.Categories[name+"/"+category]; found {
+				return settings
+			}
+		}
+	}
+
+	return &config.CategorySettings{}
+}
+
+// getDateParser returns the parser of the dates with the user-defined date
+// formats, the ones given on the command line are tried first, then the one
+// of the category and the ones in the configuration. Unix timestamps are
+// taken in the epoch unit of the category, dates are looked for in its date
+// column first and inside the lines if the category says so.
+func (p *PipelineBuilder) getDateParser(category *config.CategorySettings) (*parser.DateParser, error) {
+	specs := append([]string{}, p.settings.DateFormats...)
+
+	if category.DateFormat != "" {
+		specs = append(specs, category.DateFormat)
+	}
+
+	return parser.NewDateParser(parser.DateOptions{
+		Formats:   append(specs, p.settings.Config.DateFormats...),
+		EpochUnit: category.EpochUnit,
+		Column:    category.DateColumn,
+		Search:    category.DateSearch,
+	})
+}
+
+// getFormat returns the format used for parsing the log lines. The format
+// selected on the command line overrides the one set for the category, the
+// default is the generic one.
+func (p *PipelineBuilder) getFormat(category *config.CategorySettings) (parser.Format, error) {
+	dates, err := p.getDateParser(category)
+
+	if err != nil {
+		return nil, err
+	}
+
+	options := parser.FormatOptions{Fields: category.Fields, Dates: dates}
+
+	name := p.settings.Format
+	if name == "" {
+		name = category.Format
+	}
+
+	switch name {
+	case "":
+		name = "generic"
+
+	case "json":
+		options.TimeKey = p.settings.Config.JSON.TimeKey
+
+	case "logfmt":
+		options.TimeKey = p.settings.Config.Logfmt.TimeKey
+	}
+
+	if category.TimeKey != "" {
+		options.TimeKey = category.TimeKey
+	}
+
+	return parser.GetFormat(name, options)
+}
+
+// getRecordStart returns the function detecting the first lines of
+// multi-line records, nil if lines are not grouped into records
+func (p *PipelineBuilder) getRecordStart(category *config.CategorySettings, format parser.Format) (parser.RecordStartFunc, error) {
+	if !p.settings.Multiline && !category.Multiline && category.RecordStart == "" {
+		return nil, nil
+	}
+
+	return parser.NewRecordStartFunc(format, category.RecordStart)
+}
+
+func (p *PipelineBuilder) Execute() {
+	// Filters are compiled first so that a mistyped one is reported
+	// before any of the log files are opened
+	filters, err := p.compileFilters()
+
+	if err != nil {
+		log.Fatalf("ERROR: %s", err)
+	}
+
+	if p.settings.Location != nil {
+		parser.SetLocation(p.settings.Location)
+	}
+
+	category := GetCategorySettings(p.settings.Config, p.settings.Category)
+	format, err := p.getFormat(category)
+
+	if err != nil {
+		log.Fatalf("ERROR: %s", err)
+	}
+
+	recordStart, err := p.getRecordStart(category, format)
+
+	if err != nil {
+		log.Fatalf("ERROR: invalid record_start pattern: %s", err)
+	}
+
+	chain := p.getChain()
+
 	logReader := chain.Between(p.settings.Interval)
-	logPipeline := NewLogPipeline(NewTimeAwareBufferedReader(logReader, p.settings.Interval))
+	logPipeline := NewLogPipeline(NewTimeAwareBufferedReader(logReader, p.settings.Interval, format),
+		format, recordStart)
 
 	filterPipeline := NewFilterPipeline(logPipeline.Start(), filters)
 	transformPipeline := NewTransformPipeline(filterPipeline.Start(), p.settings.Fields)
@@ -13,6 +13,7 @@ const readBlockSize = 1024 * 1024
 type TimeAwareBufferedReader struct {
 	reader       io.Reader
 	interval     *types.TimeInterval
+	format       parser.Format
 	buffer       []byte
 	bufferPos    int
 	bufferLen    int
@@ -21,8 +22,8 @@ type TimeAwareBufferedReader struct {
 	endError     error
 }
 
-func NewTimeAwareBufferedReader(reader io.Reader, interval *types.TimeInterval) *TimeAwareBufferedReader {
-	return &TimeAwareBufferedReader{reader: reader, interval: interval}
+func NewTimeAwareBufferedReader(reader io.Reader, interval *types.TimeInterval, format parser.Format) *TimeAwareBufferedReader {
+	return &TimeAwareBufferedReader{reader: reader, interval: interval, format: format}
 }
 
 func (r *TimeAwareBufferedReader) readNext() error {
@@ -70,7 +71,7 @@ func (r *TimeAwareBufferedReader) seek() error {
 
 		var date *time.Time
 
-		date = parseLastDate(r.buffer, r.bufferLen)
+		date = parseLastDate(r.buffer, r.bufferLen, r.format)
 
 		if date != nil && !date.Before(r.interval.StartTime) {
 			r.startReached = true
@@ -110,7 +111,7 @@ func (r *TimeAwareBufferedReader) Read(slice []byte) (int, error) {
 			r.bufferPos += readFromBuffer
 		}
 
-		if date := parseLastDate(r.buffer, r.bufferPos); date != nil && date.After(r.interval.EndTime) {
+		if date := parseLastDate(r.buffer, r.bufferPos, r.format); date != nil && date.After(r.interval.EndTime) {
 			err = io.EOF
 		} else if r.ended && r.bufferPos >= r.bufferLen {
 			err = r.endError
@@ -120,7 +121,13 @@ func (r *TimeAwareBufferedReader) Read(slice []byte) (int, error) {
 	return currentSlicePos, err
 }
 
-func extractLastFullLine(buffer []byte, length int) []byte {
+// maxDatelessLines limits how many lines are checked backwards from the end
+// of the buffer when looking for the last date
+const maxDatelessLines = 100
+
+// extractLastFullLine returns the bounds of the last full line that ends
+// before length
+func extractLastFullLine(buffer []byte, length int) (int, int) {
 	lineBounds := []int{0, length}
 	currentBound := 1
 
@@ -131,20 +138,25 @@ func extractLastFullLine(buffer []byte, length int) []byte {
 		}
 	}
 
-	return buffer[lineBounds[0]:lineBounds[1]]
+	return lineBounds[0], lineBounds[1]
 }
 
-func parseLastDate(buffer []byte, length int) *time.Time {
-	var date *time.Time
+// parseLastDate returns the date of the last line having one, lines without
+// a date (e.g. parts of stack traces) are skipped
+func parseLastDate(buffer []byte, length int, format parser.Format) *time.Time {
 	end := length
 
-	for date == nil && end > 0 {
-		line := string(extractLastFullLine(buffer, length))
-		date = parser.ParseDate(line)
-		end -= len(line)
+	for i := 0; i < maxDatelessLines && end > 0; i++ {
+		start, lineEnd := extractLastFullLine(buffer, end)
+
+		if date := parser.ParseLineDate(format, string(buffer[start:lineEnd])); date != nil {
+			return date
+		}
+
+		end = start
 	}
 
-	return date
+	return nil
 }
 
 func min(a, b int) int {
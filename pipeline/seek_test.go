@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kbence/logan/parser"
 	"github.com/kbence/logan/types"
 )
 
@@ -20,7 +21,7 @@ func TestSeekRunsToEnd(t *testing.T) {
 
 	buffer := make([]byte, 16)
 
-	bufferedReader := NewTimeAwareBufferedReader(reader, types.NewTimeInterval(startTime, endTime))
+	bufferedReader := NewTimeAwareBufferedReader(reader, types.NewTimeInterval(startTime, endTime), &parser.GenericFormat{})
 
 	for idx, expected := range expectedChunks {
 		var l int
@@ -54,7 +55,7 @@ func TestSeekFirstReadHitsEnd(t *testing.T) {
 
 	buffer := make([]byte, 1024)
 
-	bufferedReader := NewTimeAwareBufferedReader(reader, types.NewTimeInterval(startTime, endTime))
+	bufferedReader := NewTimeAwareBufferedReader(reader, types.NewTimeInterval(startTime, endTime), &parser.GenericFormat{})
 	length, err = bufferedReader.Read(buffer)
 
 	if length != 42 {
@@ -82,7 +83,7 @@ func TestSeekWithReadLine(t *testing.T) {
 	logReader := strings.NewReader(logContent)
 
 	multiReader := io.MultiReader(logReader)
-	bufferedReader := NewTimeAwareBufferedReader(multiReader, types.NewTimeInterval(startTime, endTime))
+	bufferedReader := NewTimeAwareBufferedReader(multiReader, types.NewTimeInterval(startTime, endTime), &parser.GenericFormat{})
 	reader := bufio.NewReader(bufferedReader)
 
 	line1, err1 := reader.ReadString('\n')
@@ -109,3 +110,32 @@ func TestSeekWithReadLine(t *testing.T) {
 		t.Errorf("Expected error io.EOF at end, got '%s' instead!", err2)
 	}
 }
+
+func TestParseLastDateSkipsLinesWithoutDate(t *testing.T) {
+	location, _ := time.LoadLocation("Local")
+	expected := time.Date(2017, 2, 26, 8, 0, 6, 0, location)
+	buffer := []byte("2017-02-26 08:00:05 first\n2017-02-26 08:00:06 exception\n\tat Main.java:1\n\tat Ma")
+
+	if date := parseLastDate(buffer, len(buffer), &parser.GenericFormat{}); date == nil || !date.Equal(expected) {
+		t.Errorf("Last date (%v) != expected (%v)", date, expected)
+	}
+
+	buffer = []byte("no dates\nat all\n")
+
+	if date := parseLastDate(buffer, len(buffer), &parser.GenericFormat{}); date != nil {
+		t.Errorf("Buffer without dates returned %v", date)
+	}
+}
+
+func TestParseLastDateFindsDateInsideLine(t *testing.T) {
+	expected := time.Date(2017, 2, 26, 8, 0, 6, 0, time.FixedZone("", 3600))
+	buffer := []byte("1.2.3.4 - - [26/Feb/2017:08:00:05 +0100] \"GET / HTTP/1.1\" 200 5\n" +
+		"1.2.3.4 - - [26/Feb/2017:08:00:06 +0100] \"GET / HTTP/1.1\" 200 5\n1.2.3.4 - -")
+
+	dates, _ := parser.NewDateParser(parser.DateOptions{Search: true})
+	format, _ := parser.GetFormat("generic", parser.FormatOptions{Dates: dates})
+
+	if date := parseLastDate(buffer, len(buffer), format); date == nil || !date.Equal(expected) {
+		t.Errorf("Last date (%v) != expected (%v)", date, expected)
+	}
+}
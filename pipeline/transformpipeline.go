@@ -11,7 +11,7 @@ func NewTransformPipeline(input types.LogLineChannel, fields []*types.IntInterva
 	return &TransformPipeline{inputChannel: input, selectedFields: fields}
 }
 
-func createFieldList(intervals []*types.IntInterval, columns map[int]string) []int {
+func createFieldList(intervals []*types.IntInterval, columns map[int]string, names types.ColumnNames) []int {
 	fieldList := []int{}
 	maxFieldID := 1
 
@@ -22,6 +22,13 @@ func createFieldList(intervals []*types.IntInterval, columns map[int]string) []i
 	}
 
 	for _, interval := range intervals {
+		if interval.Name != "" {
+			if fieldID, found := names[interval.Name]; found {
+				fieldList = append(fieldList, fieldID)
+			}
+			continue
+		}
+
 		for fieldID := interval.Start; fieldID <= interval.End && fieldID <= maxFieldID; fieldID++ {
 			fieldList = append(fieldList, fieldID)
 		}
@@ -38,10 +45,22 @@ func selectFields(output types.LogLineChannel, input types.LogLineChannel, field
 			break
 		}
 
-		newLine := &types.LogLine{Line: line.Line, Date: line.Date, Columns: map[int]string{}}
+		newLine := &types.LogLine{Line: line.Line, Date: line.Date, Columns: map[int]string{},
+			Continuation: line.Continuation}
+
+		columnNames := map[int]string{}
 
-		for idx, f := range createFieldList(fields, line.Columns) {
+		if line.Names != nil {
+			newLine.Names = types.ColumnNames{}
+			columnNames = line.Names.ByColumn()
+		}
+
+		for idx, f := range createFieldList(fields, line.Columns, line.Names) {
 			newLine.Columns[idx] = line.Columns[f]
+
+			if name, found := columnNames[f]; found {
+				newLine.Names[name] = idx
+			}
 		}
 
 		output <- newLine
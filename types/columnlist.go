@@ -8,6 +8,9 @@ import (
 
 type ColumnList map[int]string
 
+// ColumnNames maps the names of columns to their numbers
+type ColumnNames map[string]int
+
 var crc64Table = crc64.MakeTable(crc64.ECMA)
 
 func (l ColumnList) Equals(other ColumnList) bool {
@@ -52,3 +55,14 @@ func (l ColumnList) SortedKeys() []int {
 	sort.Ints(keys)
 	return keys
 }
+
+// ByColumn returns the names keyed by column number
+func (n ColumnNames) ByColumn() map[int]string {
+	names := map[int]string{}
+
+	for name, column := range n {
+		names[column] = name
+	}
+
+	return names
+}
@@ -22,9 +22,12 @@ func (t *TimeInterval) Contains(tm time.Time) bool {
 	return !(tm.Before(t.StartTime) || tm.After(t.EndTime))
 }
 
+// IntInterval is a range of column numbers, or a single named column if
+// Name is set
 type IntInterval struct {
 	Start int
 	End   int
+	Name  string
 }
 
 func NewIntInterval(start, end int) *IntInterval {
@@ -33,6 +36,7 @@ func NewIntInterval(start, end int) *IntInterval {
 
 var singleIntIntervalMatcher = regexp.MustCompile("^[0-9]+$")
 var fromToIntIntervalMatcher = regexp.MustCompile("^([0-9]+)-([0-9]+)$")
+var columnNameMatcher = regexp.MustCompile("^\\.?([a-zA-Z_@][a-zA-Z0-9_.@-]*)$")
 
 func ParseIntInterval(interval string) *IntInterval {
 	if singleIntIntervalMatcher.MatchString(interval) {
@@ -46,6 +50,10 @@ func ParseIntInterval(interval string) *IntInterval {
 		return NewIntInterval(int(start), int(end))
 	}
 
+	if matches := columnNameMatcher.FindStringSubmatch(interval); matches != nil {
+		return &IntInterval{Name: matches[1]}
+	}
+
 	log.Panicf("Interval cannot be parsed: %s", interval)
 
 	// Unreachable code
@@ -63,5 +71,9 @@ func ParseAllIntIntervals(intervals string) []*IntInterval {
 }
 
 func (i *IntInterval) String() string {
+	if i.Name != "" {
+		return fmt.Sprintf("[Interval %s]", i.Name)
+	}
+
 	return fmt.Sprintf("[Interval %d-%d]", i.Start, i.End)
 }
@@ -1,11 +1,18 @@
 package types
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type LogLine struct {
 	Line    string
 	Date    time.Time
 	Columns ColumnList
+	Names   ColumnNames
+	// Continuation holds the lines following Line that belong to the same
+	// record (e.g. a stack trace), if lines are grouped into records
+	Continuation []string
 }
 
 type LogLineChannel chan *LogLine
@@ -17,3 +24,25 @@ func NewLogLineChannel() LogLineChannel {
 func (l *LogLine) String() string {
 	return l.Line
 }
+
+// Text returns the whole record, including the continuation lines
+func (l *LogLine) Text() string {
+	if len(l.Continuation) == 0 {
+		return l.Line
+	}
+
+	return l.Line + "\n" + strings.Join(l.Continuation, "\n")
+}
+
+// Field returns the value of a named column, the second return value is
+// false if the line has no column with that name
+func (l *LogLine) Field(name string) (string, bool) {
+	column, found := l.Names[name]
+
+	if !found {
+		return "", false
+	}
+
+	value, found := l.Columns[column]
+	return value, found
+}
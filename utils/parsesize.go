@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var sizeUnitMap = map[string]float64{
+	"":  1,
+	"b": 1,
+	"k": 1 << 10,
+	"m": 1 << 20,
+	"g": 1 << 30,
+	"t": 1 << 40,
+	"p": 1 << 50,
+}
+
+// ParseSize parses a byte size like "14KB", "1.5M" or "512b" and returns
+// the number of bytes. Units are case-insensitive and 1024-based, "KB",
+// "KiB" and "K" all mean kilobytes.
+func ParseSize(s string) (float64, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+
+	i := 0
+	for ; i < len(s); i++ {
+		c := s[i]
+		if !(c == '.' || c == '-' || c == '+' || '0' <= c && c <= '9') {
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, errors.New("size: invalid size " + orig)
+	}
+
+	u := strings.ToLower(s[i:])
+	if len(u) > 1 {
+		u = strings.TrimSuffix(u, "b")
+	}
+	if len(u) > 1 {
+		u = strings.TrimSuffix(u, "i")
+	}
+
+	multiplier, ok := sizeUnitMap[u]
+	if !ok {
+		return 0, errors.New("size: unknown unit " + s[i:] + " in size " + orig)
+	}
+
+	return number * multiplier, nil
+}
@@ -0,0 +1,38 @@
+package utils
+
+import "testing"
+
+func testSize(t *testing.T, size string, expected float64) {
+	bytes, err := ParseSize(size)
+
+	if err != nil {
+		t.Errorf("ParseSize(%s) failed: %s", size, err)
+	} else if bytes != expected {
+		t.Errorf("ParseSize(%s) (%f) != expected (%f)", size, bytes, expected)
+	}
+}
+
+func testInvalidSize(t *testing.T, size string) {
+	if _, err := ParseSize(size); err == nil {
+		t.Errorf("ParseSize(%s) should have failed", size)
+	}
+}
+
+func TestParseSizeHandlesUnits(t *testing.T) {
+	testSize(t, "512", 512)
+	testSize(t, "512b", 512)
+	testSize(t, "14KB", 14*1024)
+	testSize(t, "14kb", 14*1024)
+	testSize(t, "1.5M", 1.5*1024*1024)
+	testSize(t, "2GiB", 2*1024*1024*1024)
+	testSize(t, "1TB", 1024*1024*1024*1024)
+}
+
+func TestParseSizeRejectsInvalidSizes(t *testing.T) {
+	testInvalidSize(t, "")
+	testInvalidSize(t, "KB")
+	testInvalidSize(t, "14XB")
+	testInvalidSize(t, "14KBB")
+	testInvalidSize(t, "14ib")
+	testInvalidSize(t, "1.2.3M")
+}
@@ -98,3 +98,19 @@ func TestParseTimeInterval(t *testing.T) {
 			time.Date(2016, 9, 26, 12, 30, 0, 0, location),
 			time.Date(2016, 9, 26, 12, 35, 0, 0, location)))
 }
+
+func TestParseTimeIntervalUsesTimeZoneOfNow(t *testing.T) {
+	budapest, _ := time.LoadLocation("Europe/Budapest")
+	now := time.Date(2016, 10, 3, 13, 5, 12, 0, time.UTC)
+
+	utcInterval := ParseTimeInterval("11:30+5m", now)
+	budapestInterval := ParseTimeInterval("11:30+5m", now.In(budapest))
+
+	if !utcInterval.StartTime.Equal(time.Date(2016, 10, 3, 11, 30, 0, 0, time.UTC)) {
+		t.Errorf("Interval in UTC starts at %s", utcInterval.StartTime)
+	}
+
+	if !budapestInterval.StartTime.Equal(time.Date(2016, 10, 3, 11, 30, 0, 0, budapest)) {
+		t.Errorf("Interval in Europe/Budapest starts at %s", budapestInterval.StartTime)
+	}
+}